@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"os"
 	"os/exec"
@@ -9,6 +10,10 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/vmihailenco/msgpack/v5"
+	"github.com/xuri/excelize/v2"
 )
 
 var cliBin string
@@ -517,3 +522,458 @@ func TestCLI_NoInput_ShowsHelp(t *testing.T) {
 		t.Fatalf("expected help text with 'Flags:', got: %s", out)
 	}
 }
+
+func TestCLI_GzipCSVFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	p := filepath.Join(tmpDir, "users.csv.gz")
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, _ = zw.Write([]byte("name,age\nAlice,30\nBob,25\n"))
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	out, errOut, code, err := runCLI(t, []string{"-f", p, "--where", "age>26", "--style", "csv"}, nil)
+	if err != nil || code != 0 {
+		t.Fatalf("err=%v code=%d stderr=%s", err, code, errOut)
+	}
+	if out != "age,name\n30,Alice\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+
+	// Compressed stdin is detected by its magic bytes
+	out, errOut, code, err = runCLI(t, []string{"--style", "csv"}, buf.Bytes())
+	if err != nil || code != 0 {
+		t.Fatalf("err=%v code=%d stderr=%s", err, code, errOut)
+	}
+	if !strings.Contains(out, "Bob") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestCLI_MultipleFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	for name, content := range map[string]string{
+		"a.jsonl": "{\"name\":\"Alice\"}\n",
+		"b.jsonl": "{\"name\":\"Bob\",\"age\":25}\n",
+	} {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	args := []string{"-f", filepath.Join(tmpDir, "*.jsonl"), "--source-column", "src", "--select", "name,age,src", "--style", "csv"}
+	out, errOut, code, err := runCLI(t, args, nil)
+	if err != nil || code != 0 {
+		t.Fatalf("err=%v code=%d stderr=%s", err, code, errOut)
+	}
+	want := "name,age,src\nAlice,null," + filepath.Join(tmpDir, "a.jsonl") + "\nBob,25," + filepath.Join(tmpDir, "b.jsonl") + "\n"
+	if out != want {
+		t.Fatalf("got %q, want %q", out, want)
+	}
+}
+
+func TestCLI_Encoding(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// UTF-16LE with BOM, as written by Windows tools
+	p := filepath.Join(tmpDir, "utf16.csv")
+	var utf16 []byte
+	utf16 = append(utf16, 0xff, 0xfe)
+	for _, r := range "name,city\nZoë,Köln\n" {
+		utf16 = append(utf16, byte(r), byte(r>>8))
+	}
+	if err := os.WriteFile(p, utf16, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	out, errOut, code, err := runCLI(t, []string{"-f", p, "--style", "csv"}, nil)
+	if err != nil || code != 0 {
+		t.Fatalf("err=%v code=%d stderr=%s", err, code, errOut)
+	}
+	if out != "city,name\nKöln,Zoë\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+
+	// Windows-1252 selected explicitly
+	out, errOut, code, err = runCLI(t, []string{"-F", "csv", "--encoding", "windows-1252", "--style", "csv"}, []byte("price\n\x805\n"))
+	if err != nil || code != 0 {
+		t.Fatalf("err=%v code=%d stderr=%s", err, code, errOut)
+	}
+	if out != "price\n€5\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+
+	_, errOut, code, _ = runCLI(t, []string{"--encoding", "klingon", "-i", "{}"}, nil)
+	if code != 2 || !strings.Contains(errOut, "unsupported encoding") {
+		t.Fatalf("expected usage error, code=%d stderr=%s", code, errOut)
+	}
+}
+
+func TestCLI_TOML(t *testing.T) {
+	tmpDir := t.TempDir()
+	p := filepath.Join(tmpDir, "servers.toml")
+	content := "[[servers]]\nname = \"alpha\"\nport = 8080\n[servers.tls]\nenabled = true\n\n[[servers]]\nname = \"beta\"\nport = 8081\n"
+	if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	out, errOut, code, err := runCLI(t, []string{"-f", p, "--dive", "--sort", "-port", "--style", "csv"}, nil)
+	if err != nil || code != 0 {
+		t.Fatalf("err=%v code=%d stderr=%s", err, code, errOut)
+	}
+	if out != "name,port,tls.enabled\nbeta,8081,null\nalpha,8080,true\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+
+	// A config document renders as key/value pairs, sniffed from stdin
+	out, errOut, code, err = runCLI(t, []string{"--dive", "--style", "csv"}, []byte("title = \"svc\"\n[owner]\nname = \"ops\"\n"))
+	if err != nil || code != 0 {
+		t.Fatalf("err=%v code=%d stderr=%s", err, code, errOut)
+	}
+	if out != "KEY,VALUE\nowner.name,ops\ntitle,svc\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestCLI_XMLRowPath(t *testing.T) {
+	xml := `<catalog><book id="b1"><title>Go</title><price>30</price></book><book id="b2"><title>XML</title><price>45</price></book></catalog>`
+	out, errOut, code, err := runCLI(t, []string{"--xml-row-path", "catalog.book", "--where", "price>40", "--style", "csv"}, []byte(xml))
+	if err != nil || code != 0 {
+		t.Fatalf("err=%v code=%d stderr=%s", err, code, errOut)
+	}
+	if out != "@id,price,title\nb2,45,XML\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+
+	_, errOut, code, _ = runCLI(t, []string{"--xml-row-path", "catalog.dvd"}, []byte(xml))
+	if code != 4 || !strings.Contains(errOut, "not found") {
+		t.Fatalf("expected parse error, code=%d stderr=%s", code, errOut)
+	}
+}
+
+func TestCLI_OnError(t *testing.T) {
+	in := []byte("{\"level\":\"info\"}\nnot json\n{\"level\":\"error\"}\n")
+
+	_, errOut, code, _ := runCLI(t, []string{"-F", "jsonl"}, in)
+	if code != 4 || !strings.Contains(errOut, "line 2, column 2") {
+		t.Fatalf("expected parse error with location, code=%d stderr=%s", code, errOut)
+	}
+
+	out, errOut, code, err := runCLI(t, []string{"-F", "jsonl", "--on-error", "skip", "--style", "csv"}, in)
+	if err != nil || code != 0 {
+		t.Fatalf("err=%v code=%d stderr=%s", err, code, errOut)
+	}
+	if out != "level\ninfo\nerror\n" || !strings.Contains(errOut, "skipped line 2") {
+		t.Fatalf("unexpected output: %q stderr=%q", out, errOut)
+	}
+}
+
+func TestCLI_CSVDialect(t *testing.T) {
+	// Semicolon separated exports are detected as CSV rather than YAML
+	out, errOut, code, err := runCLI(t, []string{"--style", "csv"}, []byte("name;price\nKäse;\"1.50\"\nBrot;2\n"))
+	if err != nil || code != 0 {
+		t.Fatalf("err=%v code=%d stderr=%s", err, code, errOut)
+	}
+	if out != "name,price\nKäse,1.50\nBrot,2\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+
+	out, errOut, code, err = runCLI(t, []string{"--tsv", "--csv-skip-rows", "1", "--csv-comment", "#", "--csv-trim-space", "--style", "csv"},
+		[]byte("exported by tool\na\t b\n# skipped\n1\t 2 \n"))
+	if err != nil || code != 0 {
+		t.Fatalf("err=%v code=%d stderr=%s", err, code, errOut)
+	}
+	if out != "a,b\n1,2\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+
+	_, errOut, code, _ = runCLI(t, []string{"--delimiter", "ab", "-i", "a"}, nil)
+	if code != 2 || !strings.Contains(errOut, "invalid --delimiter") {
+		t.Fatalf("expected usage error, code=%d stderr=%s", code, errOut)
+	}
+}
+
+func TestCLI_CSVInferTypes(t *testing.T) {
+	in := []byte("name,score,active\nAlice,9.5,true\nBob,10.25,false\nCarol,,NULL\n")
+	out, errOut, code, err := runCLI(t, []string{"--csv-infer-types", "--sort", "-score", "--precision", "1", "--bool-str", "yes:no", "--style", "csv"}, in)
+	if err != nil || code != 0 {
+		t.Fatalf("err=%v code=%d stderr=%s", err, code, errOut)
+	}
+	if out != "active,name,score\nno,Bob,10.2\nyes,Alice,9.5\nnull,Carol,null\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+
+	_, errOut, code, _ = runCLI(t, []string{"--csv-types", "score:int"}, in)
+	if code != 4 || !strings.Contains(errOut, `"9.5" is not an int`) {
+		t.Fatalf("expected parse error, code=%d stderr=%s", code, errOut)
+	}
+}
+
+func TestCLI_XLSX(t *testing.T) {
+	f := excelize.NewFile()
+	for i, row := range [][]any{{"item", "amount"}, {"rent", 1200}, {"food", 350.5}} {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := f.SetSheetRow("Sheet1", cell, &row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := f.NewSheet("Q2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.SetSheetRow("Q2", "A1", &[]any{"item"}); err != nil {
+		t.Fatal(err)
+	}
+	p := filepath.Join(t.TempDir(), "budget.xlsx")
+	if err := f.SaveAs(p); err != nil {
+		t.Fatal(err)
+	}
+
+	out, errOut, code, err := runCLI(t, []string{"-f", p, "--sort", "amount", "--precision", "2", "--style", "csv"}, nil)
+	if err != nil || code != 0 {
+		t.Fatalf("err=%v code=%d stderr=%s", err, code, errOut)
+	}
+	if out != "amount,item\n350.50,food\n1200.00,rent\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+
+	_, errOut, code, _ = runCLI(t, []string{"-f", p, "--sheet", "Q3"}, nil)
+	if code != 4 || !strings.Contains(errOut, "available sheets: Sheet1, Q2") {
+		t.Fatalf("expected parse error, code=%d stderr=%s", code, errOut)
+	}
+}
+
+func TestCLI_Parquet(t *testing.T) {
+	type address struct {
+		City string `parquet:"city"`
+	}
+	type order struct {
+		ID      int64     `parquet:"id"`
+		Placed  time.Time `parquet:"placed,timestamp(millisecond)"`
+		Items   []string  `parquet:"items,list"`
+		Address address   `parquet:"address"`
+	}
+	p := filepath.Join(t.TempDir(), "orders.parquet")
+	orders := []order{
+		{ID: 2, Placed: time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC), Items: []string{"pen"}, Address: address{City: "Oslo"}},
+		{ID: 1, Placed: time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC), Items: []string{"ink", "pad"}, Address: address{City: "Rome"}},
+	}
+	if err := parquet.WriteFile(p, orders); err != nil {
+		t.Fatal(err)
+	}
+
+	out, errOut, code, err := runCLI(t, []string{"-f", p, "--dive", "--select", "id,placed,address.city", "--sort", "id", "--style", "csv"}, nil)
+	if err != nil || code != 0 {
+		t.Fatalf("err=%v code=%d stderr=%s", err, code, errOut)
+	}
+	if out != "id,placed,address.city\n1,2024-05-01T09:30:00Z,Rome\n2,2024-05-02,Oslo\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+
+	out, errOut, code, err = runCLI(t, []string{"-f", p, "--dive", "--select", "address.city", "--where", "id=1", "--style", "csv"}, nil)
+	if err != nil || code != 0 {
+		t.Fatalf("err=%v code=%d stderr=%s", err, code, errOut)
+	}
+	if out != "address.city\nRome\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestCLI_MsgpackFrames(t *testing.T) {
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	for _, frame := range []map[string]any{
+		{"svc": "api", "status": 500, "meta": map[int]string{1: "retry"}},
+		{"svc": "db", "status": 200},
+	} {
+		if err := enc.Encode(frame); err != nil {
+			t.Fatal(err)
+		}
+	}
+	p := filepath.Join(t.TempDir(), "frames.msgpack")
+	if err := os.WriteFile(p, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, errOut, code, err := runCLI(t, []string{"-f", p, "--dive", "--where", "status>=500", "--style", "csv"}, nil)
+	if err != nil || code != 0 {
+		t.Fatalf("err=%v code=%d stderr=%s", err, code, errOut)
+	}
+	if out != "meta.1,status,svc\nretry,500,api\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestCLI_Logfmt(t *testing.T) {
+	logs := `ts=2024-05-01T10:00:02Z level=error msg="disk full" host=b
+ts=2024-05-01T10:00:00Z level=info msg=started host=a
+ts=2024-05-01T10:00:01Z level=error msg="conn reset" host=a
+`
+	out, errOut, code, err := runCLI(t, []string{"--where", "level=error", "--sort", "ts", "--select", "ts,host,msg", "--style", "csv"}, []byte(logs))
+	if err != nil || code != 0 {
+		t.Fatalf("err=%v code=%d stderr=%s", err, code, errOut)
+	}
+	want := "ts,host,msg\n2024-05-01T10:00:01Z,a,conn reset\n2024-05-01T10:00:02Z,b,disk full\n"
+	if out != want {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestCLI_RegexPreset(t *testing.T) {
+	logs := `203.0.113.9 - - [01/May/2024:10:00:00 +0000] "GET /api HTTP/1.1" 502 157 "-" "curl/8.0"
+garbage line
+198.51.100.4 - - [01/May/2024:10:00:01 +0000] "GET / HTTP/1.1" 200 612 "-" "Mozilla/5.0"
+`
+	args := []string{"--pattern", "nginx-combined", "--on-error", "skip", "--where", "status>=500", "--select", "remote_addr,status,request", "--style", "csv"}
+	out, errOut, code, err := runCLI(t, args, []byte(logs))
+	if err != nil || code != 0 {
+		t.Fatalf("err=%v code=%d stderr=%s", err, code, errOut)
+	}
+	if out != "remote_addr,status,request\n203.0.113.9,502,GET /api HTTP/1.1\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+	if !strings.Contains(errOut, `skipped line 2: line does not match pattern in "garbage line"`) {
+		t.Fatalf("unexpected stderr: %q", errOut)
+	}
+
+	_, errOut, code, _ = runCLI(t, []string{"-F", "regex"}, []byte("x\n"))
+	if code != 2 || !strings.Contains(errOut, "requires --pattern") {
+		t.Fatalf("expected usage error, code=%d stderr=%s", code, errOut)
+	}
+}
+
+func TestCLI_Columns(t *testing.T) {
+	ps := `CONTAINER ID   IMAGE        STATUS                   NAMES
+a1b2c3d4e5f6   nginx:1.25   Up 2 hours               web
+0f9e8d7c6b5a   redis:7      Exited (0) 2 hours ago   cache
+`
+	out, errOut, code, err := runCLI(t, []string{"-F", "columns", "--where", "STATUS~Up", "--select", "NAMES,IMAGE", "--style", "csv"}, []byte(ps))
+	if err != nil || code != 0 {
+		t.Fatalf("err=%v code=%d stderr=%s", err, code, errOut)
+	}
+	if out != "NAMES,IMAGE\nweb,nginx:1.25\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestCLI_MarkdownAndHTMLRoundTrip(t *testing.T) {
+	data := []byte(`[{"name":"api","port":8080},{"name":"db","port":5432}]`)
+	for _, style := range []string{"markdown", "html"} {
+		table, errOut, code, err := runCLI(t, []string{"--style", style}, data)
+		if err != nil || code != 0 {
+			t.Fatalf("%s: err=%v code=%d stderr=%s", style, err, code, errOut)
+		}
+		out, errOut, code, err := runCLI(t, []string{"-F", style, "--where", "port>6000", "--style", "csv"}, []byte(table))
+		if err != nil || code != 0 {
+			t.Fatalf("%s: err=%v code=%d stderr=%s", style, err, code, errOut)
+		}
+		if out != "name,port\napi,8080\n" {
+			t.Fatalf("%s: unexpected output: %q", style, out)
+		}
+	}
+
+	_, errOut, code, _ := runCLI(t, []string{"-F", "markdown", "--table-index", "2"}, []byte("| a |\n|---|\n| 1 |\n"))
+	if code != 4 || !strings.Contains(errOut, "table 2 not found") {
+		t.Fatalf("expected parse error, code=%d stderr=%s", code, errOut)
+	}
+}
+
+func TestCLI_Root(t *testing.T) {
+	resp := []byte(`{"data":{"items":[{"id":1},{"id":2}]},"meta":{"total":2}}`)
+	out, errOut, code, err := runCLI(t, []string{"--root", "data.items", "--style", "csv"}, resp)
+	if err != nil || code != 0 {
+		t.Fatalf("err=%v code=%d stderr=%s", err, code, errOut)
+	}
+	if out != "id\n1\n2\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+
+	_, errOut, code, _ = runCLI(t, []string{"--root", "data.rows"}, resp)
+	if code != 5 || !strings.Contains(errOut, `root path "data.rows" not found`) {
+		t.Fatalf("expected selection error, code=%d stderr=%s", code, errOut)
+	}
+}
+
+func TestCLI_AutoRoot(t *testing.T) {
+	runs := []byte(`{"total_count":2,"workflow_runs":[{"id":7,"status":"completed"},{"id":8,"status":"queued"}]}`)
+	out, errOut, code, err := runCLI(t, []string{"--auto-root", "--where", "status=queued", "--style", "csv"}, runs)
+	if err != nil || code != 0 {
+		t.Fatalf("err=%v code=%d stderr=%s", err, code, errOut)
+	}
+	if out != "id,status\n8,queued\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+	if !strings.Contains(errOut, `using rows at "workflow_runs"`) {
+		t.Fatalf("expected hint on stderr, got %q", errOut)
+	}
+}
+
+func TestCLI_RowsFromMap(t *testing.T) {
+	users := []byte(`{"carol":{"age":35},"alice":{"age":30},"bob":{"age":31}}`)
+	out, errOut, code, err := runCLI(t, []string{"--rows-from-map", "--key-column", "id", "--where", "age>30", "--sort", "-age", "--select", "id,age", "--style", "csv"}, users)
+	if err != nil || code != 0 {
+		t.Fatalf("err=%v code=%d stderr=%s", err, code, errOut)
+	}
+	if out != "id,age\ncarol,35\nbob,31\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestCLI_Orient(t *testing.T) {
+	frame := []byte(`{"columns":["city","temp"],"data":[["Oslo",4],["Rome",19]]}`)
+	out, errOut, code, err := runCLI(t, []string{"--orient", "auto", "--where", "temp>10", "--style", "csv"}, frame)
+	if err != nil || code != 0 {
+		t.Fatalf("err=%v code=%d stderr=%s", err, code, errOut)
+	}
+	if out != "city,temp\nRome,19\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+
+	_, _, code, _ = runCLI(t, []string{"--orient", "columns"}, []byte(`[1,2]`))
+	if code != 5 {
+		t.Fatalf("expected exit code 5 for a shape mismatch, got %d", code)
+	}
+}
+
+func TestCLI_Explode(t *testing.T) {
+	orders := []byte(`[{"id":1,"customer":"ann","items":[{"sku":"A","qty":2},{"sku":"B","qty":1}]},{"id":2,"customer":"bo","items":[{"sku":"A","qty":5}]}]`)
+	out, errOut, code, err := runCLI(t, []string{"--explode", "items", "--where", "items.sku=A", "--select", "id,customer,items.qty", "--style", "csv"}, orders)
+	if err != nil || code != 0 {
+		t.Fatalf("err=%v code=%d stderr=%s", err, code, errOut)
+	}
+	if out != "id,customer,items.qty\n1,ann,2\n2,bo,5\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestCLI_NestedDivePath(t *testing.T) {
+	manifest := []byte("kind: Deployment\nmetadata:\n  name: web\n  labels:\n    app: web\n  annotations:\n    note: x\nspec:\n  replicas: 2\n")
+	out, errOut, code, err := runCLI(t, []string{"-F", "yaml", "--dive-path", "metadata.lab*", "--style", "csv"}, manifest)
+	if err != nil || code != 0 {
+		t.Fatalf("err=%v code=%d stderr=%s", err, code, errOut)
+	}
+	want := "KEY,VALUE\nkind,Deployment\nmetadata.annotations,\"{\\\"note\\\":\\\"x\\\"}\"\nmetadata.labels.app,web\nmetadata.name,web\nspec,\"{\\\"replicas\\\":2}\"\n"
+	if out != want {
+		t.Fatalf("unexpected output: %q", out)
+	}
+
+	_, _, code, _ = runCLI(t, []string{"--dive-path", "a(*"}, []byte(`{}`))
+	if code != 2 {
+		t.Fatalf("expected usage exit code for an invalid --dive-path, got %d", code)
+	}
+}
+
+func TestCLI_QuotedPaths(t *testing.T) {
+	pods := []byte(`[{"name":"web","labels":{"app.kubernetes.io/name":"web","app.kubernetes.io/part-of":"shop"}},{"name":"db","labels":{"app.kubernetes.io/name":"db"}}]`)
+	out, errOut, code, err := runCLI(t, []string{"-d",
+		"--select", `name,labels."app.kubernetes.io/name"`,
+		"--where", `labels."app.kubernetes.io/name"~b`,
+		"--sort", `-labels["app.kubernetes.io/name"]`,
+		"--style", "csv"}, pods)
+	if err != nil || code != 0 {
+		t.Fatalf("err=%v code=%d stderr=%s", err, code, errOut)
+	}
+	if out != "name,\"labels.\\\"app.kubernetes.io/name\\\"\"\nweb,web\ndb,db\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
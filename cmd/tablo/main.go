@@ -3,11 +3,14 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
 
 	"github.com/sriharip316/tablo/internal/app"
+	"github.com/sriharip316/tablo/internal/keypath"
 )
 
 // version is injected at build time using:
@@ -32,14 +35,40 @@ func main() {
 	}
 
 	// input
-	root.Flags().StringVarP(&config.Input.File, "file", "f", "", "Path to input file")
+	root.Flags().StringArrayVarP(&config.Input.Files, "file", "f", nil, "Path, glob or http(s) URL of input files (repeatable; gzip, zstd, bzip2 and xz are decompressed automatically)")
 	root.Flags().StringVarP(&config.Input.String, "input", "i", "", "Raw input string")
-	root.Flags().StringVarP(&config.Input.Format, "format", "F", "auto", "Input format: auto|json|jsonl|yaml|yml|csv")
-	root.Flags().BoolVar(&config.Input.CSVNoHeader, "csv-no-header", false, "Treat CSV input as having no header row")
+	root.Flags().StringVarP(&config.Input.Format, "format", "F", "auto", "Input format: auto|json|jsonl|logfmt|regex|yaml|yml|csv|tsv|columns|markdown|html|toml|xml|xlsx|parquet|msgpack|cbor")
+	root.Flags().BoolVar(&config.Input.CSVNoHeader, "csv-no-header", false, "Treat CSV or xlsx input as having no header row")
+	root.Flags().BoolVar(&config.Input.TSV, "tsv", false, "Read tab-separated input; same as --format tsv")
+	root.Flags().StringVar(&config.Input.Delimiter, "delimiter", "auto", "CSV field delimiter, e.g. ';', '|' or '\\t'; auto = sniff ',', ';', tab or '|' from the header")
+	root.Flags().StringVar(&config.Input.CSVComment, "csv-comment", "", "Skip CSV lines starting with this character (e.g., '#')")
+	root.Flags().BoolVar(&config.Input.CSVLazyQuotes, "csv-lazy-quotes", false, "Allow bare and unescaped quotes in CSV fields")
+	root.Flags().BoolVar(&config.Input.CSVTrimSpace, "csv-trim-space", false, "Trim leading and trailing whitespace from CSV fields")
+	root.Flags().BoolVar(&config.Input.CSVInferTypes, "csv-infer-types", false, "Convert CSV cells to numbers, booleans, timestamps and null (empty or NULL)")
+	root.Flags().StringVar(&config.Input.CSVTypes, "csv-types", "", "Per-column CSV types, e.g. 'age:int,active:bool,joined:date' (string|int|float|bool|date)")
+	root.Flags().IntVar(&config.Input.CSVSkipRows, "csv-skip-rows", 0, "Skip this many preamble lines before the CSV header")
+	root.Flags().StringVar(&config.Input.Pattern, "pattern", "", "Regex with named groups as columns for -F regex, or a preset: nginx-combined|apache-common|apache-combined|syslog")
+	root.Flags().StringVar(&config.Input.OnError, "on-error", "fail", "Handling of malformed JSONL/logfmt lines, CSV records and lines not matching --pattern: fail|skip|collect")
+	root.Flags().StringVar(&config.Input.Sheet, "sheet", "", "Worksheet of xlsx input, by name or 1-based index (default first sheet)")
+	root.Flags().StringVar(&config.Input.Root, "root", "", "Path of the rows within the input, e.g. 'data.items', 'items[0].children' or 'results.*.hits'")
+	root.Flags().BoolVar(&config.Input.AutoRoot, "auto-root", false, "Build the table from the only array of objects inside an object, such as 'items', and report its path")
+	root.Flags().StringVar(&config.Input.Orient, "orient", "", "Reshape column-oriented input into rows: auto|columns|split|matrix")
+	root.Flags().BoolVar(&config.Input.RowsFromMap, "rows-from-map", false, "Make each value of an object a row, keyed by ID as in {\"alice\":{...},\"bob\":{...}}")
+	root.Flags().StringVar(&config.Input.KeyColumn, "key-column", "", "Column holding the object keys with --rows-from-map (default KEY)")
+	root.Flags().IntVar(&config.Input.TableIndex, "table-index", 1, "Table to read from markdown or html input, by 1-based index")
+	root.Flags().StringVar(&config.Input.XMLRowPath, "xml-row-path", "", "Dotted path of the repeated XML element to use as rows (e.g., 'catalog.book')")
+	root.Flags().StringVar(&config.Input.Encoding, "encoding", "", "Input character encoding, e.g. utf-16le, latin1, windows-1252, shift_jis (default UTF-8; a BOM takes precedence)")
+	root.Flags().StringVar(&config.Input.SourceColumn, "source-column", "", "Add a column with the file each row came from (e.g., '_file')")
+	root.Flags().StringArrayVar(&config.Input.HTTPHeaders, "header", nil, "HTTP header for URL inputs, e.g. 'Authorization: Bearer TOKEN' (repeatable)")
+	root.Flags().DurationVar(&config.Input.HTTPTimeout, "timeout", 30*time.Second, "Timeout for fetching URL inputs; 0 = no timeout")
+	root.Flags().BoolVar(&config.Input.Follow, "follow", false, "Keep reading a growing JSONL/logfmt/regex/CSV file or stdin and print rows as they arrive")
+	root.Flags().IntVar(&config.Input.FollowSample, "follow-sample", 10, "Rows used to fix columns and widths in --follow mode")
 
 	// flatten
 	root.Flags().BoolVarP(&config.Flatten.Enabled, "dive", "d", false, "Enable flattening of nested objects and arrays of objects")
-	root.Flags().StringSliceVarP(&config.Flatten.Paths, "dive-path", "D", nil, "Dive only into listed top-level paths (repeatable)")
+	root.Flags().VarP((*pathList)(&config.Flatten.Paths), "dive-path", "D", "Dive only into these paths, e.g. 'metadata.labels' or 'spec.template.*' (repeatable)")
+	root.Flags().StringVar(&config.Flatten.PathSeparator, "path-separator", app.PathSeparator, "Separator of path segments in column names and path flags; quote keys holding it, e.g. 'labels.\"app.kubernetes.io/name\"'")
+	root.Flags().Var((*pathList)(&config.Flatten.Explode), "explode", "Emit one row per element of the array at this path, repeating the other fields (repeatable, e.g. 'items' or 'order.lines')")
 	root.Flags().IntVarP(&config.Flatten.MaxDepth, "max-depth", "m", -1, "Maximum depth to dive; -1 = unlimited")
 	root.Flags().BoolVar(&config.Flatten.FlattenSimpleArray, "flatten-simple-arrays", false, "Flatten arrays of primitives to comma-separated strings")
 
@@ -50,10 +79,10 @@ func main() {
 	root.Flags().BoolVar(&config.Selection.StrictSelect, "strict-select", false, "Error when any selected path does not exist")
 
 	// filtering
-	root.Flags().StringSliceVarP(&config.Filter.WhereExprs, "where", "w", nil, "Filter rows by condition (e.g., 'name=John', 'age>25')")
+	root.Flags().VarP((*pathList)(&config.Filter.WhereExprs), "where", "w", "Filter rows by condition (e.g., 'name=John', 'age>25')")
 
 	// sorting
-	root.Flags().StringSliceVar(&config.Sort.Columns, "sort", nil, "Sort by columns; use +/- prefix for direction (e.g., 'name,-age' or '+name,-age')")
+	root.Flags().Var((*pathList)(&config.Sort.Columns), "sort", "Sort by columns; use +/- prefix for direction (e.g., 'name,-age' or '+name,-age')")
 
 	// output formatting
 	root.Flags().StringVar(&config.Output.Style, "style", "heavy", "Table style: heavy|light|double|ascii|markdown|compact|borderless|html|csv")
@@ -84,7 +113,7 @@ func main() {
 // hasNoInput checks if no input source is provided (no string input, no file input, no stdin data)
 func hasNoInput(config *app.Config) bool {
 	// If string input or file input is provided, we have input
-	if config.Input.String != "" || config.Input.File != "" {
+	if config.Input.String != "" || len(config.Input.Files) > 0 {
 		return false
 	}
 
@@ -125,3 +154,21 @@ func handleError(err error, quiet bool) {
 
 	os.Exit(exitCode)
 }
+
+// pathList is a repeatable flag of comma-separated paths, like a
+// StringSlice flag, except that commas and quotes inside quoted path
+// segments such as labels."app.kubernetes.io/name" are kept.
+type pathList []string
+
+func (p *pathList) String() string {
+	return "[" + strings.Join(*p, ",") + "]"
+}
+
+func (p *pathList) Set(s string) error {
+	*p = append(*p, keypath.SplitList(s)...)
+	return nil
+}
+
+func (p *pathList) Type() string {
+	return "strings"
+}
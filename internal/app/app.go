@@ -1,13 +1,19 @@
 package app
 
 import (
+	"bufio"
+	"errors"
+	"fmt"
 	"io"
 	"os"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/sriharip316/tablo/internal/filter"
 	"github.com/sriharip316/tablo/internal/flatten"
 	"github.com/sriharip316/tablo/internal/input"
+	"github.com/sriharip316/tablo/internal/keypath"
 	"github.com/sriharip316/tablo/internal/parse"
 	"github.com/sriharip316/tablo/internal/render"
 	"github.com/sriharip316/tablo/internal/selectors"
@@ -26,10 +32,36 @@ type Config struct {
 }
 
 type InputConfig struct {
-	File        string
-	String      string
-	Format      string
-	CSVNoHeader bool
+	Files        []string
+	String       string
+	Format       string
+	CSVNoHeader  bool
+	SourceColumn string
+	HTTPHeaders  []string
+	HTTPTimeout  time.Duration
+	Follow       bool
+	FollowSample int
+	Encoding     string
+	XMLRowPath   string
+	Sheet        string
+	OnError      string
+	Pattern      string // regex or preset for the regex format
+	TableIndex   int    // 1-based Markdown or HTML table; 0 = first
+	Root         string // path of the rows within the parsed document
+	AutoRoot     bool   // descend into the only array of objects of an object
+	RowsFromMap  bool   // turn an object's values into rows
+	KeyColumn    string // column holding the keys with RowsFromMap
+	Orient       string // auto, columns, split or matrix; "" = as parsed
+
+	// CSV dialect
+	TSV           bool
+	Delimiter     string
+	CSVComment    string
+	CSVLazyQuotes bool
+	CSVTrimSpace  bool
+	CSVSkipRows   int
+	CSVInferTypes bool
+	CSVTypes      string
 }
 
 type FlattenConfig struct {
@@ -37,6 +69,8 @@ type FlattenConfig struct {
 	Paths              []string
 	MaxDepth           int
 	FlattenSimpleArray bool
+	Explode            []string
+	PathSeparator      string // joins key segments; "" = PathSeparator
 }
 
 type SelectionConfig struct {
@@ -79,6 +113,7 @@ type GeneralConfig struct {
 type Application struct {
 	config Config
 	stdin  io.Reader
+	stderr io.Writer
 }
 
 // New creates a new Application instance
@@ -86,9 +121,16 @@ func New(config Config, stdin io.Reader) *Application {
 	return &Application{
 		config: config,
 		stdin:  stdin,
+		stderr: os.Stderr,
 	}
 }
 
+// WithStderr sets where diagnostics such as skipped rows are reported.
+func (app *Application) WithStderr(w io.Writer) *Application {
+	app.stderr = w
+	return app
+}
+
 // Run executes the main application logic
 func (app *Application) Run() error {
 	// Validate configuration
@@ -96,22 +138,34 @@ func (app *Application) Run() error {
 		return err
 	}
 
-	// Read input
-	data, err := app.readInput()
+	// Resolve input files, expanding glob patterns
+	files, err := input.ExpandPaths(app.config.Input.Files)
 	if err != nil {
 		return NewError(ErrCodeInput, "failed to read input", err)
 	}
 
-	// Parse data
-	parsed, err := app.parseData(data)
-	if err != nil {
-		return NewError(ErrCodeParse, "failed to parse input", err)
+	// Render rows of a growing input as they arrive
+	if app.config.Input.Follow {
+		file := ""
+		if len(files) == 1 {
+			file = files[0]
+		}
+		return app.runFollow(file)
 	}
 
-	// Process data (flatten, select, etc.)
-	model, err := app.processData(parsed)
+	// Read, parse and process input
+	var model render.Model
+	if len(files) > 1 || app.config.Input.SourceColumn != "" {
+		model, err = app.processFiles(files)
+	} else {
+		file := ""
+		if len(files) == 1 {
+			file = files[0]
+		}
+		model, err = app.processInput(file)
+	}
 	if err != nil {
-		return NewError(ErrCodeProcessing, "failed to process data", err)
+		return err
 	}
 
 	// Render output
@@ -129,44 +183,309 @@ func (app *Application) Run() error {
 }
 
 func (app *Application) validateConfig() error {
-	if app.config.Input.String != "" && app.config.Input.File != "" {
+	if app.config.Input.String != "" && len(app.config.Input.Files) > 0 {
 		return NewError(ErrCodeUsage, "conflicting inputs: --input and --file cannot be used together", nil)
 	}
-	return nil
+	if app.config.Input.SourceColumn != "" && len(app.config.Input.Files) == 0 {
+		return NewError(ErrCodeUsage, "--source-column requires --file", nil)
+	}
+	if app.config.Input.Follow {
+		if err := app.validateFollow(); err != nil {
+			return err
+		}
+	}
+	if _, err := input.ParseHeaders(app.config.Input.HTTPHeaders); err != nil {
+		return NewError(ErrCodeUsage, "invalid --header", err)
+	}
+	if _, err := input.LookupEncoding(app.config.Input.Encoding); err != nil {
+		return NewError(ErrCodeUsage, "invalid --encoding", err)
+	}
+	switch app.config.Input.OnError {
+	case "", OnErrorFail, OnErrorSkip, OnErrorCollect:
+	default:
+		return NewUsageError("invalid --on-error " + app.config.Input.OnError + ": must be skip, fail or collect")
+	}
+	if err := keypath.ValidateSeparator(app.pathSeparator()); err != nil {
+		return NewError(ErrCodeUsage, "invalid --path-separator", err)
+	}
+	if _, err := selectors.CompileManySep(app.config.Flatten.Paths, app.pathSeparator()); err != nil {
+		return NewError(ErrCodeUsage, "invalid --dive-path", err)
+	}
+	for _, path := range app.config.Flatten.Explode {
+		if _, err := keypath.Split(path, app.pathSeparator()); err != nil {
+			return NewError(ErrCodeUsage, "invalid --explode", err)
+		}
+	}
+	if app.config.Input.TableIndex < 0 {
+		return NewUsageError("--table-index must not be negative")
+	}
+	if app.config.Input.KeyColumn != "" && !app.config.Input.RowsFromMap {
+		return NewUsageError("--key-column requires --rows-from-map")
+	}
+	if err := app.validateOrient(); err != nil {
+		return err
+	}
+	if err := app.validateRoot(); err != nil {
+		return err
+	}
+	if err := app.validatePattern(); err != nil {
+		return err
+	}
+	return app.validateCSV()
 }
 
-func (app *Application) readInput() ([]byte, error) {
-	reader := input.NewReader(app.config.Input.String, app.config.Input.File, app.stdin, MaxInputSizeBytes)
-	return reader.Read()
+// processInput builds the table model from a single input source: the given
+// file, the raw input string or stdin.
+func (app *Application) processInput(file string) (render.Model, error) {
+	// Open input
+	reader := app.newReader(file)
+	stream, err := reader.Open()
+	if err != nil {
+		return render.Model{}, NewError(ErrCodeInput, "failed to read input", err)
+	}
+	defer func() { _ = stream.Close() }()
+
+	// Detect format from a leading sample so row-oriented input can be streamed.
+	// A short or failed peek is fine: the reader replays the error once the
+	// sampled bytes have been consumed.
+	br := bufio.NewReaderSize(stream, DetectSampleSize)
+	sample, _ := br.Peek(DetectSampleSize)
+	format := app.detectFormat(sample, file, reader.ContentType())
+
+	if parse.Streamable(format) {
+		// Decode, flatten and filter row by row
+		it, err := parse.NewRowIterator(br, format, app.parseOptions())
+		if err != nil {
+			return render.Model{}, NewError(ErrCodeParse, "failed to parse input", err)
+		}
+		model, err := app.processRows(app.tolerate(app.shaped(app.rooted(it), file), file), app.flattenOptions())
+		if err != nil {
+			return render.Model{}, processingError(err)
+		}
+		return model, nil
+	}
+
+	// Read input
+	data, err := input.ReadLimited(br, MaxInputSizeBytes)
+	if err != nil {
+		return render.Model{}, NewError(ErrCodeInput, "failed to read input", err)
+	}
+
+	// Parse data
+	parsed, err := parse.Parse(data, format, app.parseOptions())
+	if err != nil {
+		return render.Model{}, NewError(ErrCodeParse, "failed to parse input", err)
+	}
+	parsed, err = app.applyRoot(parsed)
+	if err != nil {
+		return render.Model{}, err
+	}
+
+	// Process data (flatten, select, etc.)
+	model, err := app.processData(parsed)
+	if err != nil {
+		return render.Model{}, NewError(ErrCodeProcessing, "failed to process data", err)
+	}
+	return model, nil
+}
+
+// processFiles concatenates the rows of several input files, each parsed
+// with its own detected format, into a single table.
+func (app *Application) processFiles(files []string) (render.Model, error) {
+	it := &fileRows{app: app, files: files}
+	defer it.Close()
+
+	model, err := app.processRows(it, app.flattenOptions())
+	if err != nil {
+		return render.Model{}, processingError(err)
+	}
+	return model, nil
+}
+
+// processingError wraps err as a processing error unless it already reports
+// an input or parse failure.
+func processingError(err error) error {
+	if IsInputError(err) || IsParseError(err) || IsSelectionError(err) {
+		return err
+	}
+	return NewError(ErrCodeProcessing, "failed to process data", err)
 }
 
-func (app *Application) parseData(data []byte) (any, error) {
+// rowError reports a RowIterator failure reading subject: a malformed row
+// is a parse error and anything else, such as a failed read, an input
+// error. Errors the iterator has already classified are kept.
+func rowError(subject string, err error) error {
+	var appErr *AppError
+	if AsAppError(err, &appErr) {
+		return err
+	}
+	var rowErr *parse.RowError
+	if !errors.As(err, &rowErr) {
+		return NewInputError("failed to read "+subject, err)
+	}
+	return parseError("failed to parse "+subject, err)
+}
+
+// parseError wraps err as a parse error, recording the location of a
+// malformed row.
+func parseError(message string, err error) *AppError {
+	appErr := NewParseError(message, err)
+	var rowErr *parse.RowError
+	if errors.As(err, &rowErr) {
+		appErr.Line, appErr.Column = rowErr.Line, rowErr.Column
+	}
+	return appErr
+}
+
+// newReader returns an input reader for file, which may be empty to read
+// the raw input string or stdin, or an http(s) URL. Input detected as a
+// binary format is not transcoded.
+func (app *Application) newReader(file string) *input.Reader {
+	reader := input.NewReader(app.config.Input.String, file, app.stdin, MaxInputSizeBytes)
+	return reader.
+		WithHTTP(input.HTTPOptions{
+			Headers: app.config.Input.HTTPHeaders,
+			Timeout: app.config.Input.HTTPTimeout,
+		}).
+		WithEncoding(app.config.Input.Encoding).
+		WithBinary(func(head []byte) bool {
+			return parse.Binary(app.detectFormat(head, file, reader.ContentType()))
+		})
+}
+
+func (app *Application) detectFormat(sample []byte, file, contentType string) parse.Format {
 	detector := parse.Detector{
-		Explicit: app.config.Input.Format,
-		FilePath: app.config.Input.File,
+		Explicit:    app.config.Input.Format,
+		FilePath:    file,
+		ContentType: contentType,
+	}
+	if app.config.Input.TSV {
+		detector.Explicit = FormatTSV
+	}
+	if app.config.Input.Pattern != "" {
+		detector.Explicit = FormatRegex
+	}
+	comment, _ := csvRune(app.config.Input.CSVComment)
+	return detector.Detect(skipComments(skipLines(sample, app.config.Input.CSVSkipRows), comment))
+}
+
+func (app *Application) parseOptions() parse.ParseOptions {
+	// The dialect runes and column types have been checked by validateCSV,
+	// and the pattern by validatePattern
+	delim, _ := csvRune(app.config.Input.Delimiter)
+	comment, _ := csvRune(app.config.Input.CSVComment)
+	types, _ := parse.ParseColumnTypes(app.config.Input.CSVTypes)
+	var pattern *regexp.Regexp
+	if app.config.Input.Pattern != "" {
+		pattern, _ = parse.CompilePattern(app.config.Input.Pattern)
+	}
+	return parse.ParseOptions{
+		CSVNoHeader:   app.config.Input.CSVNoHeader,
+		CSVDelimiter:  delim,
+		CSVComment:    comment,
+		CSVLazyQuotes: app.config.Input.CSVLazyQuotes,
+		CSVTrimSpace:  app.config.Input.CSVTrimSpace,
+		CSVSkipRows:   app.config.Input.CSVSkipRows,
+		CSVInferTypes: app.config.Input.CSVInferTypes,
+		CSVTypes:      types,
+		XMLRowPath:    app.config.Input.XMLRowPath,
+		TOMLDocument:  app.config.Input.Root != "",
+		Transcoded:    app.config.Input.Encoding != "",
+		Sheet:         app.config.Input.Sheet,
+		Columns:       app.projectedColumns(),
+		Pattern:       pattern,
+		TableIndex:    app.config.Input.TableIndex,
+		JSONLArrays:   app.reshapes(),
+	}
+}
+
+// projectedColumns returns the top-level columns that --select, --where and
+// --sort refer to, so columnar formats can skip decoding the rest. It
+// returns nil, meaning all columns, without --select, when a selector
+// starts with a glob, or when --root moves the rows below the top level.
+func (app *Application) projectedColumns() []string {
+	if app.config.Input.Root != "" {
+		return nil
 	}
-	format := detector.Detect(data)
-	opts := parse.ParseOptions{
-		CSVNoHeader: app.config.Input.CSVNoHeader,
+	include, _, err := app.compileSelectors()
+	if err != nil || len(include) == 0 {
+		return nil
+	}
+	var columns []string
+	for _, expr := range include {
+		root, ok := expr.Root()
+		if !ok {
+			return nil
+		}
+		columns = append(columns, root)
+	}
+	if conditions, err := filter.ParseConditions(app.config.Filter.WhereExprs); err == nil {
+		for _, c := range conditions {
+			columns = append(columns, app.pathRoot(c.Path))
+		}
 	}
-	return parse.Parse(data, format, opts)
+	for _, col := range app.config.Sort.Columns {
+		for _, name := range keypath.SplitList(col) {
+			columns = append(columns, app.pathRoot(strings.TrimLeft(name, "+-")))
+		}
+	}
+	for _, path := range app.config.Flatten.Explode {
+		columns = append(columns, app.pathRoot(path))
+	}
+	return columns
 }
 
-func (app *Application) processData(parsed any) (render.Model, error) {
-	// Normalize data structure
-	normalized := app.normalizeData(parsed)
+// pathRoot returns the name of the first segment of a path.
+func (app *Application) pathRoot(path string) string {
+	if names, err := keypath.Split(path, app.pathSeparator()); err == nil {
+		return names[0]
+	}
+	return path
+}
 
-	// Apply flattening
-	flattenOpts := flatten.Options{
+// pathSeparator returns the separator of the segments of paths and keys.
+func (app *Application) pathSeparator() string {
+	if app.config.Flatten.PathSeparator != "" {
+		return app.config.Flatten.PathSeparator
+	}
+	return PathSeparator
+}
+
+// columnKey rewrites a --where or --sort path as the flattened key it names.
+func (app *Application) columnKey(path string) (string, error) {
+	return keypath.Canonical(path, app.pathSeparator())
+}
+
+func (app *Application) flattenOptions() flatten.Options {
+	// the dive paths are validated up front
+	dive, _ := selectors.CompileManySep(app.config.Flatten.Paths, app.pathSeparator())
+	return flatten.Options{
 		Enabled:            app.config.Flatten.Enabled || len(app.config.Flatten.Paths) > 0,
 		MaxDepth:           app.config.Flatten.MaxDepth,
-		DivePaths:          app.config.Flatten.Paths,
+		DivePaths:          dive,
 		FlattenSimpleArray: app.config.Flatten.FlattenSimpleArray,
+		Explode:            app.config.Flatten.Explode,
+		Separator:          app.pathSeparator(),
 	}
+}
+
+func (app *Application) processData(parsed any) (render.Model, error) {
+	// Normalize data structure
+	normalized, err := app.shapeRows(app.normalizeData(parsed), "")
+	if err != nil {
+		return render.Model{}, err
+	}
+
+	// Apply flattening
+	flattenOpts := app.flattenOptions()
 
 	// Determine processing mode based on data structure
 	switch data := normalized.(type) {
 	case map[string]any:
+		if len(flattenOpts.Explode) > 0 {
+			// an object exploded along a path is a table of its own
+			return app.processArray([]any{data}, flattenOpts)
+		}
 		return app.processObject(data, flattenOpts)
 	case []any:
 		return app.processArray(data, flattenOpts)
@@ -237,6 +556,81 @@ func (app *Application) processArray(arr []any, flattenOpts flatten.Options) (re
 		return render.Model{}, err
 	}
 
+	return app.buildRowsModel(filteredRows)
+}
+
+// processRows consumes rows from an iterator, flattening and filtering each
+// one as it is decoded so only matching rows are retained. When no sort is
+// requested, decoding stops as soon as the row limit has been reached; rows
+// after that point are not inspected.
+//
+// As for a parsed array, input whose first row is not an object renders as
+// a VALUE column. A value that is not an object following object rows is an
+// error, since the rows filtered out before it are no longer at hand.
+func (app *Application) processRows(it parse.RowIterator, flattenOpts flatten.Options) (render.Model, error) {
+	rowFilter, err := app.compileFilter()
+	if err != nil {
+		return render.Model{}, err
+	}
+
+	limit := app.config.Output.Limit
+	stopAtLimit := limit > 0 && len(app.config.Sort.Columns) == 0
+	var rows []flatten.FlatKV
+	for n := 1; ; n++ {
+		item, err := it.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return render.Model{}, rowError("input", err)
+		}
+		item = app.normalizeData(item)
+
+		if !parse.ArrayIsObjects([]any{item}) {
+			if n > 1 {
+				return render.Model{}, NewParseError(fmt.Sprintf("row %d is not an object, unlike the rows before it", n), nil)
+			}
+			values, err := app.collectValues(item, it)
+			if err != nil {
+				return render.Model{}, err
+			}
+			return app.processArray(values, flattenOpts)
+		}
+
+		for _, row := range flatten.FlattenRows([]any{item}, flattenOpts) {
+			if rowFilter.Match(row) {
+				rows = append(rows, row)
+			}
+		}
+		if stopAtLimit && len(rows) >= limit {
+			rows = rows[:limit]
+			break
+		}
+	}
+
+	return app.buildRowsModel(rows)
+}
+
+// collectValues gathers the rows of input starting with the value first,
+// which are rendered as a VALUE column. Only the rows shown are read.
+func (app *Application) collectValues(first any, it parse.RowIterator) ([]any, error) {
+	values := []any{first}
+	limit := app.config.Output.Limit
+	for limit <= 0 || len(values) < limit {
+		item, err := it.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, rowError("input", err)
+		}
+		values = append(values, app.normalizeData(item))
+	}
+	return values, nil
+}
+
+// buildRowsModel sorts, limits and selects columns of filtered rows.
+func (app *Application) buildRowsModel(filteredRows []flatten.FlatKV) (render.Model, error) {
 	// Apply sorting
 	sortedRows := app.applySorting(filteredRows)
 
@@ -255,7 +649,7 @@ func (app *Application) processArray(arr []any, flattenOpts flatten.Options) (re
 	}
 
 	// If limit is 1, treat it as single object
-	if app.config.Output.Limit == 1 {
+	if app.config.Output.Limit == 1 && len(sortedRows) == 1 {
 		return render.Model{
 			Mode:    render.ModeObjectKV,
 			KV:      sortedRows[0],
@@ -289,7 +683,7 @@ func (app *Application) compileSelectors() (include, exclude []selectors.Expr, e
 	// Compile include selectors
 	var includePatterns []string
 	if app.config.Selection.SelectExpr != "" {
-		includePatterns = append(includePatterns, splitCommaString(app.config.Selection.SelectExpr)...)
+		includePatterns = append(includePatterns, keypath.SplitList(app.config.Selection.SelectExpr)...)
 	}
 	if app.config.Selection.SelectFile != "" {
 		filePatterns, err := app.readSelectFile()
@@ -299,7 +693,7 @@ func (app *Application) compileSelectors() (include, exclude []selectors.Expr, e
 		includePatterns = append(includePatterns, filePatterns...)
 	}
 
-	include, err = selectors.CompileMany(includePatterns)
+	include, err = selectors.CompileManySep(includePatterns, app.pathSeparator())
 	if err != nil {
 		return nil, nil, NewError(ErrCodeUsage, "invalid include selector", err)
 	}
@@ -307,10 +701,10 @@ func (app *Application) compileSelectors() (include, exclude []selectors.Expr, e
 	// Compile exclude selectors
 	var excludePatterns []string
 	if app.config.Selection.ExcludeExpr != "" {
-		excludePatterns = splitCommaString(app.config.Selection.ExcludeExpr)
+		excludePatterns = keypath.SplitList(app.config.Selection.ExcludeExpr)
 	}
 
-	exclude, err = selectors.CompileMany(excludePatterns)
+	exclude, err = selectors.CompileManySep(excludePatterns, app.pathSeparator())
 	if err != nil {
 		return nil, nil, NewError(ErrCodeUsage, "invalid exclude selector", err)
 	}
@@ -336,7 +730,11 @@ func (app *Application) readSelectFile() ([]string, error) {
 }
 
 func (app *Application) renderOutput(model render.Model) (string, error) {
-	opts := render.Options{
+	return render.Render(model, app.renderOptions())
+}
+
+func (app *Application) renderOptions() render.Options {
+	return render.Options{
 		Style:          app.config.Output.Style,
 		ASCIIOnly:      app.config.Output.ASCIIOnly,
 		NoHeader:       app.config.Output.NoHeader,
@@ -349,8 +747,6 @@ func (app *Application) renderOutput(model render.Model) (string, error) {
 		Precision:      app.config.Output.Precision,
 		Color:          app.config.Output.Color,
 	}
-
-	return render.Render(model, opts)
 }
 
 func (app *Application) applyRowFiltering(rows []flatten.FlatKV) ([]flatten.FlatKV, error) {
@@ -358,13 +754,24 @@ func (app *Application) applyRowFiltering(rows []flatten.FlatKV) ([]flatten.Flat
 		return rows, nil
 	}
 
+	rowFilter, err := app.compileFilter()
+	if err != nil {
+		return nil, err
+	}
+	return rowFilter.Apply(rows), nil
+}
+
+func (app *Application) compileFilter() (*filter.Filter, error) {
 	conditions, err := filter.ParseConditions(app.config.Filter.WhereExprs)
 	if err != nil {
 		return nil, NewError(ErrCodeUsage, "invalid filter condition", err)
 	}
-
-	rowFilter := filter.NewFilter(conditions)
-	return rowFilter.Apply(rows), nil
+	for i := range conditions {
+		if conditions[i].Path, err = app.columnKey(conditions[i].Path); err != nil {
+			return nil, NewError(ErrCodeUsage, "invalid filter condition", err)
+		}
+	}
+	return filter.NewFilter(conditions), nil
 }
 
 func (app *Application) applySorting(rows []flatten.FlatKV) []flatten.FlatKV {
@@ -375,7 +782,13 @@ func (app *Application) applySorting(rows []flatten.FlatKV) []flatten.FlatKV {
 	// Parse comma-separated column specifications
 	var expandedColumns []string
 	for _, col := range app.config.Sort.Columns {
-		expandedColumns = append(expandedColumns, splitCommaString(col)...)
+		for _, spec := range keypath.SplitList(col) {
+			name := strings.TrimLeft(spec, "+-")
+			if key, err := app.columnKey(name); err == nil {
+				spec = spec[:len(spec)-len(name)] + key
+			}
+			expandedColumns = append(expandedColumns, spec)
+		}
 	}
 
 	sortOpts := sort.Options{
@@ -387,25 +800,33 @@ func (app *Application) applySorting(rows []flatten.FlatKV) []flatten.FlatKV {
 }
 
 func (app *Application) writeOutput(output string) error {
-	var writer io.Writer = os.Stdout
-
-	if app.config.Output.FilePath != "" {
-		file, err := os.Create(app.config.Output.FilePath)
-		if err != nil {
-			return err
-		}
-		defer func() { _ = file.Close() }()
-		writer = file
+	writer, closeWriter, err := app.openOutput()
+	if err != nil {
+		return err
 	}
+	defer closeWriter()
 
 	if !endsWithNewline(output) {
 		output += "\n"
 	}
 
-	_, err := io.WriteString(writer, output)
+	_, err = io.WriteString(writer, output)
 	return err
 }
 
+// openOutput returns the output file, or stdout when none is configured,
+// along with a function releasing it.
+func (app *Application) openOutput() (io.Writer, func(), error) {
+	if app.config.Output.FilePath == "" {
+		return os.Stdout, func() {}, nil
+	}
+	file, err := os.Create(app.config.Output.FilePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return file, func() { _ = file.Close() }, nil
+}
+
 // Helper functions
 func splitCommaString(s string) []string {
 	var result []string
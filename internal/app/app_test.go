@@ -1,10 +1,17 @@
 package app
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"reflect"
+	"runtime"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/sriharip316/tablo/internal/flatten"
 )
@@ -31,7 +38,7 @@ func TestApplication_ConflictingInputs(t *testing.T) {
 	config := Config{
 		Input: InputConfig{
 			String: `{"test": true}`,
-			File:   "test.json",
+			Files:  []string{"test.json"},
 		},
 	}
 
@@ -794,3 +801,348 @@ func TestSplitCommaStringInSorting(t *testing.T) {
 		t.Errorf("expected second row name to be Bob, got %s", result[1]["name"])
 	}
 }
+
+// failingReader returns an error once the preceding data has been consumed.
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("read past limit")
+}
+
+func TestRun_StreamJSONL_StopsAtLimit(t *testing.T) {
+	outFile := filepath.Join(t.TempDir(), "out.txt")
+	stdin := io.MultiReader(
+		strings.NewReader("{\"id\":1}\n{\"id\":2}\n{\"id\":3}\n"),
+		failingReader{},
+	)
+	cfg := Config{
+		Input:  InputConfig{Format: "jsonl"},
+		Output: OutputConfig{Style: "csv", Limit: 2, FilePath: outFile},
+	}
+	if err := New(cfg, stdin).Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := os.ReadFile(outFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(b); got != "id\n1\n2\n" {
+		t.Fatalf("unexpected output: %q", got)
+	}
+}
+
+func TestRun_StreamCSV_FilterAndSort(t *testing.T) {
+	outFile := filepath.Join(t.TempDir(), "out.txt")
+	stdin := strings.NewReader("name,age\nAlice,30\nBob,25\nCarol,35\n")
+	cfg := Config{
+		Input:  InputConfig{Format: "auto"},
+		Filter: FilterConfig{WhereExprs: []string{"age>26"}},
+		Sort:   SortConfig{Columns: []string{"-age"}},
+		Output: OutputConfig{Style: "csv", FilePath: outFile},
+	}
+	if err := New(cfg, stdin).Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := os.ReadFile(outFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(b); got != "age,name\n35,Carol\n30,Alice\n" {
+		t.Fatalf("unexpected output: %q", got)
+	}
+}
+
+func TestRun_StreamCSV_CommentsBeforeHeader(t *testing.T) {
+	stdin := strings.NewReader("# exported by billing, v2, UTF-8\n\n# prices, in EUR\nname;price\nKäse;1,50\n")
+	cfg := Config{
+		Input:  InputConfig{CSVComment: "#"},
+		Output: OutputConfig{Style: "csv", FilePath: filepath.Join(t.TempDir(), "out.txt")},
+	}
+	if err := New(cfg, stdin).Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := os.ReadFile(cfg.Output.FilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(b); got != "name,price\nKäse,\"1\\,50\"\n" {
+		t.Fatalf("unexpected output: %q", got)
+	}
+}
+
+func TestRun_StreamJSONL_ParseError(t *testing.T) {
+	cfg := Config{Input: InputConfig{String: "{\"a\":1}\n{\"a\": }\n", Format: "jsonl"}}
+	err := New(cfg, nil).Run()
+	var ae *AppError
+	if !AsAppError(err, &ae) || ae.Code != ErrCodeParse {
+		t.Fatalf("expected parse error, got: %#v", err)
+	}
+}
+
+func TestRun_StreamJSONL_ReadError(t *testing.T) {
+	stdin := io.MultiReader(strings.NewReader("{\"id\":1}\n"), failingReader{})
+	cfg := Config{Input: InputConfig{Format: "jsonl"}}
+	err := New(cfg, stdin).Run()
+	var ae *AppError
+	if !AsAppError(err, &ae) || ae.Code != ErrCodeInput {
+		t.Fatalf("expected input error, got: %#v", err)
+	}
+}
+
+func TestRun_StreamJSONL_MixedPrimitives(t *testing.T) {
+	run := func(format, in string) string {
+		outFile := filepath.Join(t.TempDir(), "out.txt")
+		cfg := Config{
+			Input:  InputConfig{String: in, Format: format},
+			Output: OutputConfig{Style: "csv", FilePath: outFile},
+		}
+		if err := New(cfg, nil).Run(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		b, err := os.ReadFile(outFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+	streamed := run("jsonl", "3\n{\"id\":1}\n")
+	parsed := run("json", `[3,{"id":1}]`)
+	if streamed != parsed {
+		t.Fatalf("streamed output %q differs from parsed output %q", streamed, parsed)
+	}
+	if want := "VALUE\n3\nmap[id:1]\n"; streamed != want {
+		t.Fatalf("unexpected output: %q", streamed)
+	}
+
+	// the object rows before a primitive are not kept, so it is an error
+	cfg := Config{Input: InputConfig{String: "{\"id\":1}\n{\"id\":2}\n3\n", Format: "jsonl"}}
+	err := New(cfg, nil).Run()
+	if !IsParseError(err) || !strings.Contains(err.Error(), "row 3") {
+		t.Fatalf("expected parse error for row 3, got %v", err)
+	}
+}
+
+// droppedRows yields n rows that --where level=error discards, counting
+// those the garbage collector has reclaimed.
+type droppedRows struct {
+	n, total  int
+	reclaimed atomic.Int64
+	seen      int64 // reclaimed rows observed on the last call
+}
+
+type payload struct{ data [1 << 10]byte }
+
+func (d *droppedRows) Next() (any, error) {
+	if d.n == 0 {
+		// finalizers run asynchronously after a collection
+		for i := 0; i < 50 && d.reclaimed.Load() < int64(d.total/2); i++ {
+			runtime.GC()
+			time.Sleep(10 * time.Millisecond)
+		}
+		d.seen = d.reclaimed.Load()
+		return nil, io.EOF
+	}
+	d.n--
+	p := &payload{}
+	runtime.SetFinalizer(p, func(*payload) { d.reclaimed.Add(1) })
+	return map[string]any{"level": "info", "payload": p}, nil
+}
+
+func TestProcessRows_DoesNotKeepFilteredRows(t *testing.T) {
+	const n = 1000
+	cfg := Config{Filter: FilterConfig{WhereExprs: []string{"level=error"}}}
+	app := New(cfg, nil)
+	it := &droppedRows{n: n, total: n}
+	if _, err := app.processRows(it, app.flattenOptions()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if it.seen < n/2 {
+		t.Fatalf("only %d of %d discarded rows were reclaimed while streaming", it.seen, n)
+	}
+}
+
+func TestRun_JSONLFileWithInvalidLine(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"x.jsonl": "{\"id\":1}\n{\"id\":\n{\"id\":3}\n"})
+	file := filepath.Join(dir, "x.jsonl")
+
+	err := New(Config{Input: InputConfig{Files: []string{file}}}, nil).Run()
+	if !IsParseError(err) {
+		t.Fatalf("expected parse error, got %v", err)
+	}
+
+	cfg := Config{
+		Input:  InputConfig{Files: []string{file}, OnError: OnErrorSkip},
+		Output: OutputConfig{Style: "csv"},
+	}
+	if got := runToString(t, cfg); got != "id\n1\n3\n" {
+		t.Fatalf("unexpected output: %q", got)
+	}
+}
+
+func TestRun_StreamJSONL_Primitives(t *testing.T) {
+	outFile := filepath.Join(t.TempDir(), "out.txt")
+	cfg := Config{
+		Input:  InputConfig{String: "1\n2\n3\n", Format: "jsonl"},
+		Output: OutputConfig{Style: "csv", FilePath: outFile},
+	}
+	if err := New(cfg, nil).Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := os.ReadFile(outFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(b); got != "VALUE\n1\n2\n3\n" {
+		t.Fatalf("unexpected output: %q", got)
+	}
+}
+
+func TestApplication_ProjectedColumns(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		want   []string
+	}{
+		{"no select", Config{Sort: SortConfig{Columns: []string{"age"}}}, nil},
+		{
+			"select where and sort roots",
+			Config{
+				Selection: SelectionConfig{SelectExpr: "name,address.city"},
+				Filter:    FilterConfig{WhereExprs: []string{"tags.0=ops"}},
+				Sort:      SortConfig{Columns: []string{"-age"}},
+			},
+			[]string{"name", "address", "tags", "age"},
+		},
+		{"glob root", Config{Selection: SelectionConfig{SelectExpr: "name,*.city"}}, nil},
+		{
+			"explode root",
+			Config{Selection: SelectionConfig{SelectExpr: "id"}, Flatten: FlattenConfig{Explode: []string{"order.lines"}}},
+			[]string{"id", "order"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := New(tt.config, nil).projectedColumns()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("projectedColumns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_Explode(t *testing.T) {
+	orders := `[{"id":1,"lines":[{"sku":"A","qty":2},{"sku":"B","qty":1}]},{"id":2,"lines":[{"sku":"C","qty":5}]}]`
+	tests := []struct {
+		name  string
+		input InputConfig
+		cfg   Config
+		want  string
+	}{
+		{"rows", InputConfig{String: orders}, Config{}, "id,lines.qty,lines.sku\n1,2,A\n1,1,B\n2,5,C\n"},
+		{
+			"filter and sort on element fields",
+			InputConfig{String: orders},
+			Config{Filter: FilterConfig{WhereExprs: []string{"lines.qty>1"}}, Sort: SortConfig{Columns: []string{"-lines.qty"}}},
+			"id,lines.qty,lines.sku\n2,5,C\n1,2,A\n",
+		},
+		{"single object", InputConfig{String: `{"id":1,"lines":[{"sku":"A"},{"sku":"B"}]}`}, Config{}, "id,lines.sku\n1,A\n1,B\n"},
+		{"streamed with limit", InputConfig{String: `{"id":1,"lines":[{"sku":"A","qty":2},{"sku":"B","qty":1}]}` + "\n" + `{"id":2,"lines":[{"sku":"C","qty":5}]}` + "\n", Format: "jsonl"}, Config{Output: OutputConfig{Limit: 2}}, "id,lines.qty,lines.sku\n1,2,A\n1,1,B\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			cfg.Input = tt.input
+			cfg.Flatten.Explode = []string{"lines"}
+			cfg.Output.Style = "csv"
+			if got := runToString(t, cfg); got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_QuotedPathsAndSeparator(t *testing.T) {
+	pods := `[{"metadata":{"name":"web","labels":{"app.kubernetes.io/name":"web","tier":"front"}},"versions":{"1.0":3}},` +
+		`{"metadata":{"name":"db","labels":{"app.kubernetes.io/name":"db"}},"versions":{"1.0":1}}]`
+	tests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{
+			"quoted and bracket segments",
+			Config{
+				Flatten:   FlattenConfig{Enabled: true, MaxDepth: -1},
+				Selection: SelectionConfig{SelectExpr: `metadata.labels."app.kubernetes.io/name",versions["1.0"]`},
+				Filter:    FilterConfig{WhereExprs: []string{`metadata.labels["app.kubernetes.io/name"]!=web`}},
+			},
+			`"metadata.labels.\"app.kubernetes.io/name\"","versions.\"1.0\""` + "\ndb,1\n",
+		},
+		{
+			"custom separator",
+			Config{
+				Flatten:   FlattenConfig{Enabled: true, MaxDepth: -1, PathSeparator: "/"},
+				Selection: SelectionConfig{SelectExpr: "metadata/name,versions/*"},
+				Sort:      SortConfig{Columns: []string{"versions/1.0"}},
+			},
+			"metadata/name,versions/1.0\ndb,1\nweb,3\n",
+		},
+		{
+			"root and explode with custom separator",
+			Config{
+				Input:   InputConfig{String: `{"data":{"a.b":[{"id":1,"tags":["x","y"]}]}}`, Root: `data::"a.b"`},
+				Flatten: FlattenConfig{PathSeparator: "::", Explode: []string{"tags"}},
+			},
+			"id,tags\n1,x\n1,y\n",
+		},
+		{
+			"dotted csv header",
+			Config{
+				Input:     InputConfig{String: "user.name,age\nbob,3\nann,4\nbob,5\n", Format: "csv"},
+				Selection: SelectionConfig{SelectExpr: "user.name,age"},
+				Filter:    FilterConfig{WhereExprs: []string{"user.name=bob"}},
+				Sort:      SortConfig{Columns: []string{"-age"}},
+			},
+			"user.name,age\nbob,5\nbob,3\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			if cfg.Input.String == "" {
+				cfg.Input.String = pods
+			}
+			cfg.Output.Style = "csv"
+			if got := runToString(t, cfg); got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_InvalidPathSeparator(t *testing.T) {
+	err := New(Config{Input: InputConfig{String: "{}"}, Flatten: FlattenConfig{PathSeparator: "*"}}, nil).Run()
+	if !IsUsageError(err) {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
+
+func TestRun_LargePrettyPrintedArray(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("[\n")
+	n := 0
+	for ; b.Len() < 2*DetectSampleSize; n++ {
+		fmt.Fprintf(&b, "  {\"id\": %d, \"name\": \"row\"},\n", n)
+	}
+	fmt.Fprintf(&b, "  {\"id\": %d, \"name\": \"last\"}\n]\n", n)
+	dir := writeFiles(t, map[string]string{"bigfile": b.String()})
+
+	cfg := Config{
+		Input:  InputConfig{Files: []string{filepath.Join(dir, "bigfile")}},
+		Filter: FilterConfig{WhereExprs: []string{"name=last"}},
+		Output: OutputConfig{Style: "csv"},
+	}
+	if got, want := runToString(t, cfg), fmt.Sprintf("id,name\n%d,last\n", n); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
@@ -1,5 +1,7 @@
 package app
 
+import "time"
+
 // Default values for application configuration
 const (
 	// Input defaults
@@ -42,12 +44,39 @@ const (
 
 // Format constants
 const (
-	FormatAuto  = "auto"
-	FormatJSON  = "json"
-	FormatYAML  = "yaml"
-	FormatYML   = "yml"
-	FormatCSV   = "csv"
-	FormatJSONL = "jsonl"
+	FormatAuto     = "auto"
+	FormatJSON     = "json"
+	FormatYAML     = "yaml"
+	FormatYML      = "yml"
+	FormatCSV      = "csv"
+	FormatTSV      = "tsv"
+	FormatJSONL    = "jsonl"
+	FormatTOML     = "toml"
+	FormatXML      = "xml"
+	FormatXLSX     = "xlsx"
+	FormatParquet  = "parquet"
+	FormatMsgPack  = "msgpack"
+	FormatCBOR     = "cbor"
+	FormatLogfmt   = "logfmt"
+	FormatRegex    = "regex"
+	FormatColumns  = "columns"
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+)
+
+// Malformed row handling constants
+const (
+	OnErrorFail    = "fail"
+	OnErrorSkip    = "skip"
+	OnErrorCollect = "collect"
+)
+
+// Orientation constants
+const (
+	OrientAuto    = "auto"
+	OrientColumns = "columns"
+	OrientSplit   = "split"
+	OrientMatrix  = "matrix"
 )
 
 // Header case constants
@@ -74,10 +103,17 @@ const (
 
 // File extensions
 const (
-	ExtJSON  = ".json"
-	ExtJSONC = ".jsonc"
-	ExtYAML  = ".yaml"
-	ExtYML   = ".yml"
+	ExtJSON    = ".json"
+	ExtJSONC   = ".jsonc"
+	ExtYAML    = ".yaml"
+	ExtYML     = ".yml"
+	ExtTOML    = ".toml"
+	ExtXML     = ".xml"
+	ExtTSV     = ".tsv"
+	ExtXLSX    = ".xlsx"
+	ExtParquet = ".parquet"
+	ExtMsgPack = ".msgpack"
+	ExtCBOR    = ".cbor"
 )
 
 // Special column names
@@ -85,6 +121,8 @@ const (
 	ColumnNameValue = "VALUE"
 	ColumnNameKey   = "KEY"
 	ColumnNameIndex = "INDEX"
+	ColumnNameLine  = "_line"  // line of a malformed row with --on-error collect
+	ColumnNameError = "_error" // its error with --on-error collect
 )
 
 // Comment prefixes
@@ -110,6 +148,14 @@ const (
 	MaxColumnWidth    = 1000
 	MaxPrecision      = 20
 	MaxDepthLimit     = 100
+	DetectSampleSize  = 64 * 1024 // bytes peeked for format detection
+)
+
+// Follow mode
+const (
+	DefaultFollowSample = 10                     // rows used to size columns
+	FollowPollInterval  = 200 * time.Millisecond // wait between reads at end of a followed file
+	FollowIdleFlush     = 500 * time.Millisecond // idle time after which the column layout is fixed
 )
 
 // Messages
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sriharip316/tablo/internal/parse"
+)
+
+// validateCSV checks the CSV dialect and column type flags.
+func (app *Application) validateCSV() error {
+	in := app.config.Input
+	if in.TSV && in.Format != "" && !strings.EqualFold(in.Format, FormatAuto) && !strings.EqualFold(in.Format, FormatTSV) {
+		return NewUsageError("--tsv cannot be combined with --format " + in.Format)
+	}
+	delim, err := csvRune(in.Delimiter)
+	if err != nil {
+		return NewError(ErrCodeUsage, "invalid --delimiter", err)
+	}
+	comment, err := csvRune(in.CSVComment)
+	if err != nil {
+		return NewError(ErrCodeUsage, "invalid --csv-comment", err)
+	}
+	if comment != 0 && comment == delim {
+		return NewUsageError("--csv-comment must differ from --delimiter")
+	}
+	if in.CSVSkipRows < 0 {
+		return NewUsageError("--csv-skip-rows must not be negative")
+	}
+	if _, err := parse.ParseColumnTypes(in.CSVTypes); err != nil {
+		return NewError(ErrCodeUsage, "invalid --csv-types", err)
+	}
+	return nil
+}
+
+// csvRune parses a delimiter or comment character. "\t" and "tab" name the
+// tab character, and an empty value or "auto" yields 0.
+func csvRune(s string) (rune, error) {
+	switch strings.ToLower(s) {
+	case "", "auto":
+		return 0, nil
+	case `\t`, "tab":
+		return '\t', nil
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	if size != len(s) || r == utf8.RuneError {
+		return 0, fmt.Errorf("%q is not a single character", s)
+	}
+	if r == '"' || r == '\r' || r == '\n' {
+		return 0, fmt.Errorf("%q cannot be used", s)
+	}
+	return r, nil
+}
+
+// skipLines drops the first n lines of sample, so that preamble lines
+// skipped with --csv-skip-rows do not affect format detection.
+func skipLines(sample []byte, n int) []byte {
+	for ; n > 0; n-- {
+		i := bytes.IndexByte(sample, '\n')
+		if i < 0 {
+			return nil
+		}
+		sample = sample[i+1:]
+	}
+	return sample
+}
+
+// skipComments drops the comment and blank lines leading sample, so that
+// they do not affect format detection either.
+func skipComments(sample []byte, comment rune) []byte {
+	if comment == 0 {
+		return sample
+	}
+	prefix := []byte(string(comment))
+	for bytes.HasPrefix(sample, prefix) || bytes.HasPrefix(sample, []byte("\n")) || bytes.HasPrefix(sample, []byte("\r\n")) {
+		i := bytes.IndexByte(sample, '\n')
+		if i < 0 {
+			return nil
+		}
+		sample = sample[i+1:]
+	}
+	return sample
+}
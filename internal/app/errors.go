@@ -65,6 +65,10 @@ type AppError struct {
 	Code    ErrorCode
 	Message string
 	Cause   error
+
+	// Line and Column locate a parse error in the input, when known
+	Line   int
+	Column int
 }
 
 // Error implements the error interface
@@ -135,6 +139,12 @@ func IsParseError(err error) bool {
 	return AsAppError(err, &appErr) && appErr.Code == ErrCodeParse
 }
 
+// IsSelectionError checks if an error is a selection error
+func IsSelectionError(err error) bool {
+	var appErr *AppError
+	return AsAppError(err, &appErr) && appErr.Code == ErrCodeSelection
+}
+
 // AsAppError extracts an AppError from an error chain
 func AsAppError(err error, target **AppError) bool {
 	for err != nil {
@@ -0,0 +1,113 @@
+package app
+
+import (
+	"bufio"
+	"errors"
+	"io"
+
+	"github.com/sriharip316/tablo/internal/input"
+	"github.com/sriharip316/tablo/internal/parse"
+)
+
+// fileRows is a parse.RowIterator over the rows of several input files.
+// Files are opened lazily, one at a time, and each is decoded with its own
+// detected format. When a source column is configured, every object row
+// records the path of the file it came from.
+type fileRows struct {
+	app   *Application
+	files []string
+
+	file   string
+	cur    parse.RowIterator
+	closer io.Closer
+}
+
+func (it *fileRows) Next() (any, error) {
+	for {
+		if it.cur == nil {
+			if len(it.files) == 0 {
+				return nil, io.EOF
+			}
+			if err := it.open(it.files[0]); err != nil {
+				return nil, err
+			}
+			it.files = it.files[1:]
+		}
+
+		row, err := it.cur.Next()
+		if errors.Is(err, io.EOF) {
+			it.Close()
+			continue
+		}
+		if err != nil {
+			return nil, rowError(it.file, err)
+		}
+		if col := it.app.config.Input.SourceColumn; col != "" {
+			if m, ok := row.(map[string]any); ok {
+				m[col] = it.file
+			}
+		}
+		return row, nil
+	}
+}
+
+// open prepares the iterator for file. Streamable formats are decoded row
+// by row; other formats are parsed whole and their top-level value is
+// turned into rows.
+func (it *fileRows) open(file string) error {
+	reader := it.app.newReader(file)
+	stream, err := reader.Open()
+	if err != nil {
+		return NewInputError("failed to read "+file, err)
+	}
+	it.file, it.closer = file, stream
+
+	br := bufio.NewReaderSize(stream, DetectSampleSize)
+	sample, _ := br.Peek(DetectSampleSize)
+	format := it.app.detectFormat(sample, file, reader.ContentType())
+
+	if parse.Streamable(format) {
+		rows, err := parse.NewRowIterator(br, format, it.app.parseOptions())
+		if err != nil {
+			return NewParseError("failed to parse "+file, err)
+		}
+		it.cur = it.app.tolerate(it.app.shaped(it.app.rooted(rows), file), file)
+		return nil
+	}
+
+	data, err := input.ReadLimited(br, MaxInputSizeBytes)
+	if err != nil {
+		return NewInputError("failed to read "+file, err)
+	}
+	parsed, err := parse.Parse(data, format, it.app.parseOptions())
+	if err != nil {
+		return NewParseError("failed to parse "+file, err)
+	}
+	parsed, err = it.app.applyRoot(parsed)
+	if err != nil {
+		return err
+	}
+	rows, err := it.app.shapeRows(it.app.normalizeData(parsed), file)
+	if err != nil {
+		return NewError(ErrCodeProcessing, "failed to process "+file, err)
+	}
+	it.cur = parse.NewSliceIterator(valueRows(rows))
+	return nil
+}
+
+// Close releases the file currently being read.
+func (it *fileRows) Close() {
+	if it.closer != nil {
+		_ = it.closer.Close()
+	}
+	it.cur, it.closer = nil, nil
+}
+
+// valueRows returns the rows held by a parsed value: the elements of an
+// array, or the value itself.
+func valueRows(v any) []any {
+	if arr, ok := v.([]any); ok {
+		return arr
+	}
+	return []any{v}
+}
@@ -0,0 +1,160 @@
+package app
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func runToString(t *testing.T, cfg Config) string {
+	t.Helper()
+	cfg.Output.FilePath = filepath.Join(t.TempDir(), "out.txt")
+	if err := New(cfg, nil).Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := os.ReadFile(cfg.Output.FilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestRun_MultipleFiles_SourceColumn(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a.json": `[{"name":"Alice","age":30}]`,
+		"b.csv":  "name,city\nBob,Paris\n",
+	})
+	a, b := filepath.Join(dir, "a.json"), filepath.Join(dir, "b.csv")
+	got := runToString(t, Config{
+		Input:  InputConfig{Files: []string{a, b}, SourceColumn: "_file"},
+		Output: OutputConfig{Style: "csv", NullStr: "null"},
+	})
+	want := "_file,age,name,city\n" + a + ",30,Alice,null\n" + b + ",null,Bob,Paris\n"
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestRun_MultipleFiles_Glob(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"1.jsonl": "{\"id\":1}\n{\"id\":2}\n",
+		"2.jsonl": "{\"id\":3}\n",
+		"3.yaml":  "id: 4\n",
+	})
+	got := runToString(t, Config{
+		Input:  InputConfig{Files: []string{filepath.Join(dir, "*.jsonl"), filepath.Join(dir, "3.yaml")}},
+		Filter: FilterConfig{WhereExprs: []string{"id>1"}},
+		Output: OutputConfig{Style: "csv"},
+	})
+	if got != "id\n2\n3\n4\n" {
+		t.Fatalf("unexpected output: %q", got)
+	}
+}
+
+func TestRun_MultipleFiles_Errors(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"ok.json":  `{"a":1}`,
+		"bad.json": `{"a":`,
+	})
+	tests := []struct {
+		name  string
+		files []string
+		code  ErrorCode
+	}{
+		{"missing file", []string{filepath.Join(dir, "ok.json"), filepath.Join(dir, "nope.json")}, ErrCodeInput},
+		{"glob without matches", []string{filepath.Join(dir, "*.csv")}, ErrCodeInput},
+		{"parse error", []string{filepath.Join(dir, "ok.json"), filepath.Join(dir, "bad.json")}, ErrCodeParse},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := New(Config{Input: InputConfig{Files: tt.files}}, nil).Run()
+			var ae *AppError
+			if !AsAppError(err, &ae) || ae.Code != tt.code {
+				t.Fatalf("expected %v, got: %#v", tt.code, err)
+			}
+		})
+	}
+}
+
+func TestRun_SourceColumnRequiresFile(t *testing.T) {
+	err := New(Config{Input: InputConfig{String: `{"a":1}`, SourceColumn: "_file"}}, nil).Run()
+	if !IsUsageError(err) {
+		t.Fatalf("expected usage error, got: %v", err)
+	}
+}
+
+func TestRun_URLInput(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users":
+			w.Header().Set("Content-Type", "text/csv")
+			_, _ = io.WriteString(w, "name,age\nAlice,30\nBob,25\n")
+		default:
+			http.Error(w, "boom", http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	got := runToString(t, Config{
+		Input:  InputConfig{Files: []string{srv.URL + "/users"}},
+		Filter: FilterConfig{WhereExprs: []string{"age<30"}},
+		Output: OutputConfig{Style: "csv"},
+	})
+	if got != "age,name\n25,Bob\n" {
+		t.Fatalf("unexpected output: %q", got)
+	}
+
+	err := New(Config{Input: InputConfig{Files: []string{srv.URL + "/fail"}}}, nil).Run()
+	var ae *AppError
+	if !AsAppError(err, &ae) || ae.Code != ErrCodeInput {
+		t.Fatalf("expected input error, got: %#v", err)
+	}
+	if !strings.Contains(err.Error(), "500 Internal Server Error") {
+		t.Fatalf("expected status in message, got: %v", err)
+	}
+}
+
+func TestRun_InvalidHeader(t *testing.T) {
+	cfg := Config{Input: InputConfig{Files: []string{"https://example.com/a.json"}, HTTPHeaders: []string{"bogus"}}}
+	if err := New(cfg, nil).Run(); !IsUsageError(err) {
+		t.Fatalf("expected usage error, got: %v", err)
+	}
+}
+
+func TestRun_EncodingSkipsBinary(t *testing.T) {
+	// [{"name":"ab"}] as MessagePack
+	dir := writeFiles(t, map[string]string{"rows.msgpack": "\x91\x81\xa4name\xa2ab"})
+	got := runToString(t, Config{
+		Input:  InputConfig{Files: []string{filepath.Join(dir, "rows.msgpack")}, Encoding: "utf-16le"},
+		Output: OutputConfig{Style: "csv"},
+	})
+	if got != "name\nab\n" {
+		t.Fatalf("unexpected output: %q", got)
+	}
+}
+
+func TestRun_EncodingWithXMLDeclaration(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"p.xml": "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><p>caf\xe9</p>"})
+	got := runToString(t, Config{
+		Input:  InputConfig{Files: []string{filepath.Join(dir, "p.xml")}, Encoding: "latin1"},
+		Output: OutputConfig{Style: "csv"},
+	})
+	if got != "KEY,VALUE\np,café\n" {
+		t.Fatalf("unexpected output: %q", got)
+	}
+}
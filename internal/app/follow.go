@@ -0,0 +1,177 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/sriharip316/tablo/internal/flatten"
+	"github.com/sriharip316/tablo/internal/parse"
+	"github.com/sriharip316/tablo/internal/render"
+	"github.com/sriharip316/tablo/internal/selectors"
+)
+
+func (app *Application) validateFollow() error {
+	switch {
+	case len(app.config.Input.Files) > 1:
+		return NewUsageError("--follow accepts a single --file")
+	case len(app.config.Sort.Columns) > 0:
+		return NewUsageError("--follow cannot be combined with --sort")
+	case strings.EqualFold(app.config.Output.Style, StyleHTML):
+		return NewUsageError("--follow does not support --style html")
+	}
+	return nil
+}
+
+// followResult carries one decoded row, or the error that ended decoding,
+// from the reading goroutine to the renderer.
+type followResult struct {
+	row any
+	err error
+}
+
+// runFollow renders a growing JSONL, logfmt or CSV input as a live table. Rows are
+// flattened and filtered as they arrive. The columns and their widths are
+// fixed from the first FollowSample matching rows, or from the rows seen
+// before the input goes idle, and later rows are printed immediately.
+func (app *Application) runFollow(file string) error {
+	reader := app.newReader(file)
+	stream, err := reader.OpenFollow(FollowPollInterval)
+	if err != nil {
+		return NewError(ErrCodeInput, "failed to read input", err)
+	}
+	defer func() { _ = stream.Close() }()
+
+	br := bufio.NewReader(stream)
+	first, err := br.ReadBytes('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return NewError(ErrCodeInput, "failed to read input", err)
+	}
+	format := app.detectFormat(first, file, "")
+	if format == parse.JSON {
+		// A followed stream of JSON values is JSON Lines
+		format = parse.JSONL
+	}
+	if !parse.Streamable(format) {
+		return NewUsageError("--follow supports only JSONL, logfmt, regex and CSV input; use --format to choose one")
+	}
+	rows, err := parse.NewRowIterator(io.MultiReader(bytes.NewReader(first), br), format, app.parseOptions())
+	if err != nil {
+		return NewError(ErrCodeParse, "failed to parse input", err)
+	}
+	it := app.tolerate(app.shaped(app.rooted(rows), file), file)
+
+	rowFilter, err := app.compileFilter()
+	if err != nil {
+		return err
+	}
+
+	writer, closeWriter, err := app.openOutput()
+	if err != nil {
+		return NewError(ErrCodeOutput, "failed to write output", err)
+	}
+	defer closeWriter()
+
+	// Decode in the background so the layout can be fixed once input is idle
+	results := make(chan followResult)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			row, err := it.Next()
+			select {
+			case results <- followResult{row: row, err: err}:
+			case <-done:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	flattenOpts := app.flattenOptions()
+	sampleSize := max(app.config.Input.FollowSample, 1)
+	var (
+		out     *render.Stream
+		headers []string
+		sample  []flatten.FlatKV
+		written int
+	)
+
+	// start fixes the columns from the sampled rows and prints them
+	start := func() error {
+		headers, err = app.applySelection(selectors.HeadersUnion(sample))
+		if err != nil {
+			return NewError(ErrCodeProcessing, "failed to process data", err)
+		}
+		rows := render.FromFlatRows(sample, headers, false).Rows
+		out, err = render.NewStream(writer, headers, rows, app.config.Output.IndexColumn, app.renderOptions())
+		if err != nil {
+			return NewError(ErrCodeRender, "failed to render output", err)
+		}
+		for _, r := range rows {
+			if err := out.WriteRow(r); err != nil {
+				return NewError(ErrCodeOutput, "failed to write output", err)
+			}
+		}
+		return nil
+	}
+
+	limit := app.config.Output.Limit
+loop:
+	for limit <= 0 || written < limit {
+		select {
+		case res := <-results:
+			if errors.Is(res.err, io.EOF) {
+				break loop
+			}
+			if res.err != nil {
+				return rowError("input", res.err)
+			}
+			for _, row := range flatten.FlattenRows([]any{app.normalizeData(res.row)}, flattenOpts) {
+				if limit > 0 && written >= limit {
+					break loop
+				}
+				if !rowFilter.Match(row) {
+					continue
+				}
+				written++
+				if out == nil {
+					sample = append(sample, row)
+					if len(sample) >= sampleSize {
+						if err := start(); err != nil {
+							return err
+						}
+					}
+					continue
+				}
+				if err := out.WriteRow(render.FromFlatRows([]flatten.FlatKV{row}, headers, false).Rows[0]); err != nil {
+					return NewError(ErrCodeOutput, "failed to write output", err)
+				}
+			}
+		case <-time.After(FollowIdleFlush):
+			if out == nil && len(sample) > 0 {
+				if err := start(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if out == nil {
+		if len(sample) == 0 {
+			return nil
+		}
+		if err := start(); err != nil {
+			return err
+		}
+	}
+	if err := out.Close(); err != nil {
+		return NewError(ErrCodeOutput, "failed to write output", err)
+	}
+	return nil
+}
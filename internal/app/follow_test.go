@@ -0,0 +1,98 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRun_Follow_PicksUpAppendedRows(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "app.log")
+	outFile := filepath.Join(dir, "out.txt")
+	if err := os.WriteFile(logFile, []byte("{\"level\":\"info\",\"msg\":\"boot\"}\n{\"level\":\"error\",\"msg\":\"first\"}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := Config{
+		Input:  InputConfig{Files: []string{logFile}, Follow: true, FollowSample: 1},
+		Filter: FilterConfig{WhereExprs: []string{"level=error"}},
+		Output: OutputConfig{Style: "csv", Limit: 2, FilePath: outFile},
+	}
+	errCh := make(chan error, 1)
+	go func() { errCh <- New(cfg, nil).Run() }()
+
+	// The first matching row is printed before the file grows
+	waitForOutput(t, outFile, "error,first")
+
+	f, err := os.OpenFile(logFile, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("{\"level\":\"info\",\"msg\":\"skip\"}\n{\"level\":\"error\",\"msg\":\"second\"}\n")
+	_ = f.Close()
+
+	select {
+	case err := <-errCh:
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("follow did not stop at the row limit")
+	}
+	b, _ := os.ReadFile(outFile)
+	if got := string(b); got != "level,msg\nerror,first\nerror,second\n" {
+		t.Fatalf("unexpected output: %q", got)
+	}
+}
+
+func TestRun_Follow_StdinUntilEOF(t *testing.T) {
+	outFile := filepath.Join(t.TempDir(), "out.txt")
+	cfg := Config{
+		Input:  InputConfig{Follow: true, FollowSample: 5},
+		Output: OutputConfig{Style: "ascii", FilePath: outFile, NullStr: "null"},
+	}
+	stdin := strings.NewReader("name,age\nAlice,30\nBob,7\n")
+	if err := New(cfg, stdin).Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, _ := os.ReadFile(outFile)
+	want := "+-----+-------+\n| age | name  |\n+-----+-------+\n| 30  | Alice |\n| 7   | Bob   |\n+-----+-------+\n"
+	if string(b) != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", b, want)
+	}
+}
+
+func TestRun_Follow_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{"sort", Config{Input: InputConfig{Follow: true}, Sort: SortConfig{Columns: []string{"a"}}}},
+		{"html", Config{Input: InputConfig{Follow: true}, Output: OutputConfig{Style: "html"}}},
+		{"multiple files", Config{Input: InputConfig{Follow: true, Files: []string{"a", "b"}}}},
+		{"yaml input", Config{Input: InputConfig{Follow: true, Format: "yaml"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := New(tt.cfg, strings.NewReader("a: 1\n")).Run()
+			if !IsUsageError(err) {
+				t.Fatalf("expected usage error, got: %v", err)
+			}
+		})
+	}
+}
+
+func waitForOutput(t *testing.T, path, want string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if b, err := os.ReadFile(path); err == nil && strings.Contains(string(b), want) {
+			return
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %q in %s", want, path)
+}
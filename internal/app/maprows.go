@@ -0,0 +1,31 @@
+package app
+
+// rowsFromMap implements --rows-from-map: an object whose values are keyed
+// by ID, such as {"alice":{"age":30},"bob":{"age":31}}, becomes an array
+// with one row per entry, in key order. The key is stored in the key
+// column, replacing any field of that name, and values that are not
+// objects are stored in a VALUE column.
+func (app *Application) rowsFromMap(v any) any {
+	if !app.config.Input.RowsFromMap {
+		return v
+	}
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return v
+	}
+	keyColumn := app.config.Input.KeyColumn
+	if keyColumn == "" {
+		keyColumn = ColumnNameKey
+	}
+	keys := sortedKeys(obj)
+	rows := make([]any, 0, len(obj))
+	for _, k := range keys {
+		row, ok := obj[k].(map[string]any)
+		if !ok {
+			row = map[string]any{ColumnNameValue: obj[k]}
+		}
+		row[keyColumn] = k
+		rows = append(rows, row)
+	}
+	return rows
+}
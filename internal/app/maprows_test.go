@@ -0,0 +1,76 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun_RowsFromMap(t *testing.T) {
+	tests := []struct {
+		name  string
+		input InputConfig
+		where []string
+		want  string
+	}{
+		{
+			"default key column",
+			InputConfig{String: `{"bob":{"age":31},"alice":{"age":30}}`, RowsFromMap: true},
+			nil,
+			"KEY,age\nalice,30\nbob,31\n",
+		},
+		{
+			"named key column with filter",
+			InputConfig{String: `{"bob":{"age":31},"alice":{"age":30}}`, RowsFromMap: true, KeyColumn: "id"},
+			[]string{"age>30"},
+			"age,id\n31,bob\n",
+		},
+		{
+			"scalar values",
+			InputConfig{String: `{"lodash":"4.17.21","react":"18.2.0"}`, RowsFromMap: true, KeyColumn: "package"},
+			nil,
+			"VALUE,package\n4.17.21,lodash\n18.2.0,react\n",
+		},
+		{
+			"after root",
+			InputConfig{String: `{"resources":{"web":{"type":"vm"}}}`, Root: "resources", RowsFromMap: true},
+			nil,
+			"KEY,type\nweb,vm\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{Input: tt.input, Filter: FilterConfig{WhereExprs: tt.where}, Output: OutputConfig{Style: "csv"}}
+			if got := runToString(t, cfg); got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_RowsFromMapStreamed(t *testing.T) {
+	// as piped by echo: a single line ending in a newline reads as JSONL
+	stdin := strings.NewReader("{\"alice\":{\"age\":30}}\n{\"bob\":{\"age\":31},\"carol\":{\"age\":32}}\n")
+	cfg := Config{
+		Input:  InputConfig{RowsFromMap: true},
+		Output: OutputConfig{Style: "csv", FilePath: filepath.Join(t.TempDir(), "out.txt")},
+	}
+	if err := New(cfg, stdin).Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out, err := os.ReadFile(cfg.Output.FilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != "KEY,age\nalice,30\nbob,31\ncarol,32\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestRun_KeyColumnRequiresRowsFromMap(t *testing.T) {
+	err := New(Config{Input: InputConfig{String: "{}", KeyColumn: "id"}}, nil).Run()
+	if !IsUsageError(err) {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
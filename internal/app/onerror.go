@@ -0,0 +1,52 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sriharip316/tablo/internal/parse"
+)
+
+// tolerantRows applies the --on-error policy to the malformed rows reported
+// by a JSONL or CSV iterator. Skipped rows are reported on stderr and
+// collected rows become rows holding the line number and the error.
+type tolerantRows struct {
+	app  *Application
+	it   parse.RowIterator
+	file string
+}
+
+// tolerate wraps it with the configured --on-error policy. With the default
+// fail policy it is returned unchanged.
+func (app *Application) tolerate(it parse.RowIterator, file string) parse.RowIterator {
+	switch app.config.Input.OnError {
+	case OnErrorSkip, OnErrorCollect:
+		return &tolerantRows{app: app, it: it, file: file}
+	default:
+		return it
+	}
+}
+
+func (t *tolerantRows) Next() (any, error) {
+	for {
+		row, err := t.it.Next()
+		var rowErr *parse.RowError
+		if !errors.As(err, &rowErr) {
+			return row, err
+		}
+		if t.app.config.Input.OnError == OnErrorCollect {
+			msg := rowErr.Err.Error()
+			if t.file != "" {
+				msg = t.file + ": " + msg
+			}
+			return map[string]any{ColumnNameLine: rowErr.Line, ColumnNameError: msg}, nil
+		}
+		if !t.app.config.General.Quiet {
+			prefix := ""
+			if t.file != "" {
+				prefix = t.file + ": "
+			}
+			_, _ = fmt.Fprintln(t.app.stderr, prefix+"skipped "+rowErr.Error())
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const malformedJSONL = "{\"id\":1}\n{\"id\":\n{\"id\":3}\n"
+
+func TestRun_OnErrorFail(t *testing.T) {
+	cfg := Config{
+		Input:  InputConfig{String: malformedJSONL, Format: "jsonl"},
+		Output: OutputConfig{Style: "csv", FilePath: filepath.Join(t.TempDir(), "out.txt")},
+	}
+	err := New(cfg, nil).Run()
+	var appErr *AppError
+	if !errors.As(err, &appErr) || appErr.Code != ErrCodeParse {
+		t.Fatalf("expected parse error, got %v", err)
+	}
+	if appErr.Line != 2 || appErr.Column != 6 {
+		t.Fatalf("got line %d column %d", appErr.Line, appErr.Column)
+	}
+	if !strings.Contains(err.Error(), "line 2, column 6") {
+		t.Fatalf("message lacks location: %v", err)
+	}
+}
+
+func TestRun_OnErrorSkip(t *testing.T) {
+	var stderr bytes.Buffer
+	cfg := Config{
+		Input:  InputConfig{String: malformedJSONL, Format: "jsonl", OnError: OnErrorSkip},
+		Output: OutputConfig{Style: "csv", FilePath: filepath.Join(t.TempDir(), "out.txt")},
+	}
+	if err := New(cfg, nil).WithStderr(&stderr).Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out, err := os.ReadFile(cfg.Output.FilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != "id\n1\n3\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+	if !strings.HasPrefix(stderr.String(), `skipped line 2, column 6: unexpected end of JSON input in "{\"id\":"`) {
+		t.Fatalf("unexpected report: %q", stderr.String())
+	}
+
+	// --quiet silences the report
+	stderr.Reset()
+	cfg.General.Quiet = true
+	if err := New(cfg, nil).WithStderr(&stderr).Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stderr.Len() != 0 {
+		t.Fatalf("expected no report, got %q", stderr.String())
+	}
+}
+
+func TestRun_OnErrorCollect(t *testing.T) {
+	dir := writeFiles(t, map[string]string{"a.csv": "name,age\nAlice,30\nBob\n"})
+	file := filepath.Join(dir, "a.csv")
+	got := runToString(t, Config{
+		Input:  InputConfig{Files: []string{file}, OnError: OnErrorCollect},
+		Output: OutputConfig{Style: "csv", NullStr: "null"},
+	})
+	want := "age,name,_error,_line\n30,Alice,null,null\nnull,null," + file + ": wrong number of fields,3\n"
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestRun_OnErrorInvalid(t *testing.T) {
+	err := New(Config{Input: InputConfig{String: "{}", OnError: "ignore"}}, nil).Run()
+	if !IsUsageError(err) {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
@@ -0,0 +1,306 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sriharip316/tablo/internal/parse"
+)
+
+// validateOrient checks the --orient value.
+func (app *Application) validateOrient() error {
+	switch strings.ToLower(app.config.Input.Orient) {
+	case "", OrientAuto, OrientColumns, OrientSplit, OrientMatrix:
+		return nil
+	default:
+		return NewUsageError("invalid --orient " + app.config.Input.Orient + ": must be auto, columns, split or matrix")
+	}
+}
+
+// shapeRows applies the options that reshape a normalized document into
+// rows: --orient, --auto-root and --rows-from-map.
+func (app *Application) shapeRows(v any, file string) (any, error) {
+	v, err := app.orient(v)
+	if err != nil {
+		return nil, err
+	}
+	v, _ = app.autoRoot(v, file, true)
+	return app.rowsFromMap(v), nil
+}
+
+// shapedRows reshapes each row of a streamed input, so that a JSONL line is
+// reshaped as a document of its own. The --auto-root choice is noted on
+// stderr for the first row it is made for.
+type shapedRows struct {
+	app     *Application
+	it      parse.RowIterator
+	file    string
+	hinted  bool
+	pending []any
+}
+
+// reshapes reports whether any of the options applied by shapedRows is set.
+func (app *Application) reshapes() bool {
+	in := app.config.Input
+	return in.Orient != "" || in.AutoRoot || in.RowsFromMap
+}
+
+// shaped wraps it to reshape every row. Without --orient, --auto-root or
+// --rows-from-map it is returned unchanged. With them, JSONL lines holding
+// arrays are decoded whole, so a line can be a matrix.
+func (app *Application) shaped(it parse.RowIterator, file string) parse.RowIterator {
+	if !app.reshapes() {
+		return it
+	}
+	return &shapedRows{app: app, it: it, file: file}
+}
+
+func (s *shapedRows) Next() (any, error) {
+	for len(s.pending) == 0 {
+		row, err := s.it.Next()
+		if err != nil {
+			return nil, err
+		}
+		v, err := s.app.orient(s.app.normalizeData(row))
+		if err != nil {
+			subject := "data"
+			if s.file != "" {
+				subject = s.file
+			}
+			return nil, NewError(ErrCodeProcessing, "failed to process "+subject, err)
+		}
+		v, rooted := s.app.autoRoot(v, s.file, !s.hinted)
+		s.hinted = s.hinted || rooted
+		s.pending = valueRows(s.app.rowsFromMap(v))
+	}
+	row := s.pending[0]
+	s.pending = s.pending[1:]
+	return row, nil
+}
+
+// orient implements --orient, turning column-oriented documents into
+// arrays of row objects:
+//
+//	columns  {"a":[1,2],"b":[3,4]}, or {"a":{"0":1,"1":2},...} as written by pandas
+//	split    {"columns":["a","b"],"data":[[1,3],[2,4]]}
+//	matrix   [["a","b"],[1,3],[2,4]], whose first row is the header
+//
+// With auto, the split and matrix shapes and the array form of columns are
+// recognized; documents of other shapes are left unchanged.
+func (app *Application) orient(v any) (any, error) {
+	mode := strings.ToLower(app.config.Input.Orient)
+	switch mode {
+	case "":
+		return v, nil
+	case OrientAuto:
+		if rows, ok := splitRows(v); ok {
+			return rows, nil
+		}
+		if rows, ok := matrixRows(v); ok {
+			return rows, nil
+		}
+		if rows, ok := columnRows(v, true); ok {
+			return rows, nil
+		}
+		return v, nil
+	}
+
+	var rows []any
+	var ok bool
+	var shape string
+	switch mode {
+	case OrientColumns:
+		rows, ok = columnRows(v, false)
+		shape = "an object of equally long arrays or of index maps"
+	case OrientSplit:
+		rows, ok = splitRows(v)
+		shape = `an object with "columns" and "data" arrays`
+	case OrientMatrix:
+		rows, ok = matrixRows(v)
+		shape = "an array of arrays whose first row is the header"
+	}
+	if !ok {
+		return nil, fmt.Errorf("--orient %s expects %s", mode, shape)
+	}
+	return rows, nil
+}
+
+// columnRows zips the arrays of primitives held by an object into rows.
+// Unless detecting, values may also be objects keyed by row index, as
+// pandas writes them; when detecting, at least two columns are required.
+func columnRows(v any, detect bool) ([]any, bool) {
+	obj, ok := v.(map[string]any)
+	if !ok || len(obj) == 0 || (detect && len(obj) < 2) {
+		return nil, false
+	}
+	names := sortedKeys(obj)
+	if _, ok := obj[names[0]].(map[string]any); ok && !detect {
+		return indexMapRows(obj, names)
+	}
+
+	length := -1
+	for _, name := range names {
+		arr, ok := obj[name].([]any)
+		if !ok || (length >= 0 && len(arr) != length) {
+			return nil, false
+		}
+		length = len(arr)
+		for _, item := range arr {
+			switch item.(type) {
+			case []any, map[string]any:
+				return nil, false
+			}
+		}
+	}
+	if length == 0 {
+		return nil, false
+	}
+	rows := make([]any, length)
+	for i := range rows {
+		row := make(map[string]any, len(names))
+		for _, name := range names {
+			row[name] = obj[name].([]any)[i]
+		}
+		rows[i] = row
+	}
+	return rows, true
+}
+
+// indexMapRows builds rows from columns held as objects keyed by row index.
+// Row indices are ordered numerically when they are all numbers.
+func indexMapRows(obj map[string]any, names []string) ([]any, bool) {
+	seen := map[string]bool{}
+	var index []string
+	for _, name := range names {
+		col, ok := obj[name].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		for k := range col {
+			if !seen[k] {
+				seen[k] = true
+				index = append(index, k)
+			}
+		}
+	}
+	if len(index) == 0 {
+		return nil, false
+	}
+	sort.Slice(index, func(i, j int) bool {
+		a, errA := strconv.ParseFloat(index[i], 64)
+		b, errB := strconv.ParseFloat(index[j], 64)
+		if errA == nil && errB == nil {
+			return a < b
+		}
+		return index[i] < index[j]
+	})
+	rows := make([]any, len(index))
+	for i, idx := range index {
+		row := make(map[string]any, len(names))
+		for _, name := range names {
+			row[name] = obj[name].(map[string]any)[idx]
+		}
+		rows[i] = row
+	}
+	return rows, true
+}
+
+// splitRows reads the {"columns":[...],"data":[[...]]} shape. An "index"
+// array, as written by pandas, becomes an index column.
+func splitRows(v any) ([]any, bool) {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	columns, ok := obj["columns"].([]any)
+	if !ok {
+		return nil, false
+	}
+	data, ok := obj["data"].([]any)
+	if !ok {
+		return nil, false
+	}
+	for k := range obj {
+		switch k {
+		case "columns", "data", "index", "name":
+		default:
+			return nil, false
+		}
+	}
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = fmt.Sprint(c)
+	}
+	index, _ := obj["index"].([]any)
+	rows := make([]any, len(data))
+	for i, record := range data {
+		cells, ok := record.([]any)
+		if !ok {
+			return nil, false
+		}
+		row := zipRow(header, cells)
+		if i < len(index) {
+			row["index"] = index[i]
+		}
+		rows[i] = row
+	}
+	return rows, true
+}
+
+// matrixRows reads an array of arrays whose first row holds the column
+// names, as exported from spreadsheets.
+func matrixRows(v any) ([]any, bool) {
+	arr, ok := v.([]any)
+	if !ok || len(arr) < 2 {
+		return nil, false
+	}
+	first, ok := arr[0].([]any)
+	if !ok || len(first) == 0 {
+		return nil, false
+	}
+	header := make([]string, len(first))
+	for i, h := range first {
+		s, ok := h.(string)
+		if !ok || s == "" {
+			return nil, false
+		}
+		header[i] = s
+	}
+	rows := make([]any, 0, len(arr)-1)
+	for _, record := range arr[1:] {
+		cells, ok := record.([]any)
+		if !ok {
+			return nil, false
+		}
+		rows = append(rows, zipRow(header, cells))
+	}
+	return rows, true
+}
+
+// zipRow keys cells by header, naming cells beyond it col0, col1, ...
+// Missing cells are null.
+func zipRow(header []string, cells []any) map[string]any {
+	row := make(map[string]any, max(len(header), len(cells)))
+	for i, name := range header {
+		row[name] = nil
+		if i < len(cells) {
+			row[name] = cells[i]
+		}
+	}
+	for i := len(header); i < len(cells); i++ {
+		row[fmt.Sprintf("col%d", i)] = cells[i]
+	}
+	return row
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
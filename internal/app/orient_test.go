@@ -0,0 +1,82 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun_Orient(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		orient string
+		want   string
+	}{
+		{"columns", `{"name":["a","b"],"n":[1,2]}`, OrientColumns, "n,name\n1,a\n2,b\n"},
+		{"columns as index maps", `{"name":{"0":"a","10":"c","2":"b"},"n":{"0":1,"2":2,"10":3}}`, OrientColumns, "n,name\n1,a\n2,b\n3,c\n"},
+		{"split with index", `{"columns":["name","n"],"index":["x","y"],"data":[["a",1],["b",2]]}`, OrientSplit, "index,n,name\nx,1,a\ny,2,b\n"},
+		{"matrix with ragged rows", `[["name","n"],["a",1],["b"],["c",3,true]]`, OrientMatrix, "n,name,col2\n1,a,null\nnull,b,null\n3,c,true\n"},
+		{"auto split", `{"columns":["n"],"data":[[1],[2]]}`, OrientAuto, "n\n1\n2\n"},
+		{"auto matrix", `[["n"],[1]]`, OrientAuto, "n\n1\n"},
+		{"auto columns", `{"x":[1,2],"y":[3,4]}`, OrientAuto, "x,y\n1,3\n2,4\n"},
+		{"auto leaves records", `[{"n":1},{"n":2}]`, OrientAuto, "n\n1\n2\n"},
+		{"auto leaves unequal arrays", `{"x":[1,2],"y":[3]}`, OrientAuto, "KEY,VALUE\nx,\"[1\\,2]\"\ny,[3]\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{Input: InputConfig{String: tt.input, Orient: tt.orient}, Output: OutputConfig{Style: "csv", NullStr: "null"}}
+			if got := runToString(t, cfg); got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_OrientStreamed(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		orient string
+		want   string
+	}{
+		{"columns", "{\"name\":[\"a\",\"b\"],\"n\":[1,2]}\n", OrientColumns, "n,name\n1,a\n2,b\n"},
+		{"matrix", "[[\"n\"],[1],[2]]\n", OrientMatrix, "n\n1\n2\n"},
+		{"auto per line", "{\"x\":[1],\"y\":[3]}\n[[\"x\",\"y\"],[2,4]]\n", OrientAuto, "x,y\n1,3\n2,4\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Config{
+				Input:  InputConfig{Orient: tt.orient},
+				Output: OutputConfig{Style: "csv", FilePath: filepath.Join(t.TempDir(), "out.txt")},
+			}
+			if err := New(cfg, strings.NewReader(tt.input)).Run(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			out, err := os.ReadFile(cfg.Output.FilePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(out) != tt.want {
+				t.Fatalf("got %q, want %q", out, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_OrientErrors(t *testing.T) {
+	err := New(Config{Input: InputConfig{String: "{}", Orient: "rows"}}, nil).Run()
+	if !IsUsageError(err) {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+	err = New(Config{Input: InputConfig{String: `[{"n":1}]`, Orient: OrientMatrix}}, nil).Run()
+	if err == nil {
+		t.Fatalf("expected error for a shape that is not a matrix")
+	}
+	err = New(Config{Input: InputConfig{Orient: OrientMatrix}}, strings.NewReader("[[\"n\"],[1]]\n{\"n\":2}\n")).Run()
+	var ae *AppError
+	if !AsAppError(err, &ae) || ae.Code != ErrCodeProcessing {
+		t.Fatalf("expected processing error for a streamed line that is not a matrix, got %v", err)
+	}
+}
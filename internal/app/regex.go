@@ -0,0 +1,27 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/sriharip316/tablo/internal/parse"
+)
+
+// validatePattern checks --pattern, which selects the regex format unless
+// another format is given.
+func (app *Application) validatePattern() error {
+	in := app.config.Input
+	explicit := in.Format != "" && !strings.EqualFold(in.Format, FormatAuto)
+	if in.Pattern == "" {
+		if explicit && strings.EqualFold(in.Format, FormatRegex) {
+			return NewUsageError("--format regex requires --pattern")
+		}
+		return nil
+	}
+	if explicit && !strings.EqualFold(in.Format, FormatRegex) {
+		return NewUsageError("--pattern cannot be combined with --format " + in.Format)
+	}
+	if _, err := parse.CompilePattern(in.Pattern); err != nil {
+		return NewError(ErrCodeUsage, "invalid --pattern", err)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"testing"
+)
+
+func TestValidatePattern(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      InputConfig
+		wantErr bool
+	}{
+		{"no pattern", InputConfig{}, false},
+		{"pattern implies regex", InputConfig{Pattern: "syslog"}, false},
+		{"explicit regex", InputConfig{Format: FormatRegex, Pattern: `(?P<word>\w+)`}, false},
+		{"regex without pattern", InputConfig{Format: FormatRegex}, true},
+		{"other format", InputConfig{Format: FormatCSV, Pattern: "syslog"}, true},
+		{"no named groups", InputConfig{Pattern: `(\w+)`}, true},
+		{"invalid regex", InputConfig{Pattern: `(?P<x>`}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := New(Config{Input: tt.in}, nil).validatePattern()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validatePattern() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !IsUsageError(err) {
+				t.Fatalf("expected usage error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRun_RegexCollectsUnmatched(t *testing.T) {
+	got := runToString(t, Config{
+		Input: InputConfig{
+			String:  "INFO up\nbanner\nWARN slow\n",
+			Pattern: `^(?P<level>[A-Z]+) (?P<msg>.+)$`,
+			OnError: OnErrorCollect,
+		},
+		Output: OutputConfig{Style: "csv", NullStr: "null"},
+	})
+	want := "level,msg,_error,_line\nINFO,up,null,null\nnull,null,line does not match pattern,2\nWARN,slow,null,null\n"
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,168 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sriharip316/tablo/internal/keypath"
+	"github.com/sriharip316/tablo/internal/parse"
+	"github.com/sriharip316/tablo/internal/selectors"
+)
+
+// rootExpr compiles the --root path, in which array indices may be given
+// as items[0] or results[*].
+func (app *Application) rootExpr(path string) (selectors.Expr, error) {
+	path = strings.TrimPrefix(strings.TrimSpace(path), app.pathSeparator())
+	exprs, err := selectors.CompileManySep([]string{path}, app.pathSeparator())
+	if err != nil {
+		return selectors.Expr{}, err
+	}
+	if len(exprs) == 0 {
+		return selectors.Expr{}, fmt.Errorf("empty path")
+	}
+	return exprs[0], nil
+}
+
+// validateRoot checks the --root path.
+func (app *Application) validateRoot() error {
+	if app.config.Input.AutoRoot && app.config.Input.Root != "" {
+		return NewUsageError("--auto-root cannot be combined with --root")
+	}
+	if app.config.Input.Root == "" {
+		return nil
+	}
+	if _, err := app.rootExpr(app.config.Input.Root); err != nil {
+		return NewError(ErrCodeUsage, "invalid --root", err)
+	}
+	return nil
+}
+
+// applyRoot replaces a parsed document with the value at the --root path.
+// A path with wildcards yields the array of all values found, with arrays
+// among them concatenated, so results.*.hits gathers every hit.
+func (app *Application) applyRoot(v any) (any, error) {
+	if app.config.Input.Root == "" {
+		return v, nil
+	}
+	expr, err := app.rootExpr(app.config.Input.Root)
+	if err != nil {
+		return nil, NewError(ErrCodeUsage, "invalid --root", err)
+	}
+	found := expr.Find(v)
+	if len(found) == 0 {
+		return nil, NewSelectionError(fmt.Sprintf("root path %q not found", app.config.Input.Root))
+	}
+	if !expr.HasGlob() {
+		return found[0], nil
+	}
+	rows := []any{}
+	for _, f := range found {
+		switch t := f.(type) {
+		case []any:
+			rows = append(rows, t...)
+		case []map[string]any:
+			for _, m := range t {
+				rows = append(rows, m)
+			}
+		default:
+			rows = append(rows, f)
+		}
+	}
+	return rows, nil
+}
+
+// autoRoot implements --auto-root: when v is an object holding exactly one
+// non-empty array of objects, at any depth outside of arrays, that array
+// is returned and, if hint is set, its path reported on stderr. Otherwise v
+// is returned unchanged. The second result reports whether an array was
+// picked.
+func (app *Application) autoRoot(v any, file string, hint bool) (any, bool) {
+	if !app.config.Input.AutoRoot {
+		return v, false
+	}
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return v, false
+	}
+	paths := rowArrays(obj, nil)
+	if len(paths) != 1 {
+		return v, false
+	}
+	if hint && !app.config.General.Quiet {
+		prefix := ""
+		if file != "" {
+			prefix = file + ": "
+		}
+		path := keypath.Join(paths[0], app.pathSeparator())
+		_, _ = fmt.Fprintf(app.stderr, "%susing rows at %q (--root %s)\n", prefix, path, path)
+	}
+	arr, _ := lookupPath(obj, paths[0])
+	return arr, true
+}
+
+// rowArrays returns the paths, as segments, of the non-empty arrays of
+// objects within obj, not looking inside arrays.
+func rowArrays(obj map[string]any, prefix []string) [][]string {
+	var paths [][]string
+	for _, k := range sortedKeys(obj) {
+		path := append(prefix[:len(prefix):len(prefix)], k)
+		switch t := obj[k].(type) {
+		case []any:
+			if len(t) > 0 && parse.ArrayIsObjects(t) {
+				paths = append(paths, path)
+			}
+		case map[string]any:
+			paths = append(paths, rowArrays(t, path)...)
+		}
+	}
+	return paths
+}
+
+// lookupPath returns the value at a path of object keys.
+func lookupPath(obj map[string]any, path []string) (any, bool) {
+	var v any = obj
+	for _, key := range path {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if v, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// rootRows applies --root to each row of a streamed input, such as JSONL
+// lines holding API responses.
+type rootRows struct {
+	app     *Application
+	it      parse.RowIterator
+	pending []any
+}
+
+// rooted wraps it to apply --root to every row. Without --root it is
+// returned unchanged.
+func (app *Application) rooted(it parse.RowIterator) parse.RowIterator {
+	if app.config.Input.Root == "" {
+		return it
+	}
+	return &rootRows{app: app, it: it}
+}
+
+func (r *rootRows) Next() (any, error) {
+	for len(r.pending) == 0 {
+		row, err := r.it.Next()
+		if err != nil {
+			return nil, err
+		}
+		v, err := r.app.applyRoot(row)
+		if err != nil {
+			return nil, err
+		}
+		r.pending = valueRows(v)
+	}
+	row := r.pending[0]
+	r.pending = r.pending[1:]
+	return row, nil
+}
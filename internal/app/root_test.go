@@ -0,0 +1,149 @@
+package app
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const envelope = `{"data":{"items":[{"id":1,"name":"a"},{"id":2,"name":"b"}]},"meta":{"page":1},
+"results":[{"hits":[{"id":3}]},{"hits":[{"id":4},{"id":5}]}]}`
+
+func TestRun_Root(t *testing.T) {
+	tests := []struct {
+		name string
+		root string
+		want string
+	}{
+		{"dotted path", "data.items", "id,name\n1,a\n2,b\n"},
+		{"index", "data.items[1]", "KEY,VALUE\nid,2\nname,b\n"},
+		{"wildcard", "results.*.hits", "id\n3\n4\n5\n"},
+		{"bracket wildcard", "results[*].hits[0]", "id\n3\n4\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := runToString(t, Config{
+				Input:  InputConfig{String: envelope, Root: tt.root},
+				Output: OutputConfig{Style: "csv"},
+			})
+			if got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_RootJSONL(t *testing.T) {
+	got := runToString(t, Config{
+		Input:  InputConfig{String: "{\"items\":[{\"id\":1}]}\n{\"items\":[{\"id\":2},{\"id\":3}]}\n", Format: "jsonl", Root: "items"},
+		Output: OutputConfig{Style: "csv"},
+	})
+	if got != "id\n1\n2\n3\n" {
+		t.Fatalf("unexpected output: %q", got)
+	}
+}
+
+func TestRun_RootTOML(t *testing.T) {
+	data := "title = \"x\"\n\n[[servers]]\nname = \"a\"\n\n[[servers]]\nname = \"b\"\n"
+	for _, root := range []string{"", "servers"} {
+		got := runToString(t, Config{
+			Input:  InputConfig{String: data, Format: "toml", Root: root},
+			Output: OutputConfig{Style: "csv"},
+		})
+		if got != "name\na\nb\n" {
+			t.Fatalf("root %q: unexpected output: %q", root, got)
+		}
+	}
+	got := runToString(t, Config{
+		Input:  InputConfig{String: data, Format: "toml", Root: "title"},
+		Output: OutputConfig{Style: "csv"},
+	})
+	if got != "VALUE\nx\n" {
+		t.Fatalf("unexpected output for --root title: %q", got)
+	}
+}
+
+func TestRun_RootMissing(t *testing.T) {
+	err := New(Config{Input: InputConfig{String: envelope, Root: "data.rows"}}, nil).Run()
+	if !IsSelectionError(err) || !strings.Contains(err.Error(), `root path "data.rows" not found`) {
+		t.Fatalf("expected selection error, got %v", err)
+	}
+	err = New(Config{Input: InputConfig{String: "{\"a\":1}\n{\"b\":1}\n", Format: "jsonl", Root: "a"}}, nil).Run()
+	if !IsSelectionError(err) {
+		t.Fatalf("expected selection error for streamed rows, got %v", err)
+	}
+}
+
+func TestRun_AutoRoot(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		want     string
+		wantHint string
+	}{
+		{"kubectl items", `{"apiVersion":"v1","items":[{"name":"a"},{"name":"b"}],"kind":"List"}`, "name\na\nb\n", `using rows at "items" (--root items)`},
+		{"nested", `{"data":{"items":[{"id":1}]},"meta":{"page":1}}`, "id\n1\n", `using rows at "data.items" (--root data.items)`},
+		{"ambiguous", `{"a":[{"id":1}],"b":[{"id":2}]}`, "KEY,VALUE\na,\"[{\\\"id\\\":1}]\"\nb,\"[{\\\"id\\\":2}]\"\n", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stderr bytes.Buffer
+			cfg := Config{
+				Input:  InputConfig{String: tt.input, AutoRoot: true},
+				Output: OutputConfig{Style: "csv", FilePath: filepath.Join(t.TempDir(), "out.txt")},
+			}
+			if err := New(cfg, nil).WithStderr(&stderr).Run(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			out, err := os.ReadFile(cfg.Output.FilePath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(out) != tt.want {
+				t.Fatalf("got %q, want %q", out, tt.want)
+			}
+			if strings.TrimSpace(stderr.String()) != tt.wantHint {
+				t.Fatalf("got hint %q, want %q", stderr.String(), tt.wantHint)
+			}
+		})
+	}
+
+	err := New(Config{Input: InputConfig{String: "{}", AutoRoot: true, Root: "a"}}, nil).Run()
+	if !IsUsageError(err) {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
+
+func TestRun_AutoRootStreamed(t *testing.T) {
+	var stderr bytes.Buffer
+	stdin := strings.NewReader("{\"items\":[{\"id\":1}]}\n{\"items\":[{\"id\":2},{\"id\":3}]}\n")
+	cfg := Config{
+		Input:  InputConfig{AutoRoot: true},
+		Output: OutputConfig{Style: "csv", FilePath: filepath.Join(t.TempDir(), "out.txt")},
+	}
+	if err := New(cfg, stdin).WithStderr(&stderr).Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out, err := os.ReadFile(cfg.Output.FilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != "id\n1\n2\n3\n" {
+		t.Fatalf("unexpected output: %q", out)
+	}
+	if got := stderr.String(); got != "using rows at \"items\" (--root items)\n" {
+		t.Fatalf("expected a single hint, got %q", got)
+	}
+
+	// the hint is given once a line holds rows, not only for the first line
+	stderr.Reset()
+	stdin = strings.NewReader("{\"id\":1}\n{\"items\":[{\"id\":2}]}\n{\"items\":[{\"id\":3}]}\n")
+	if err := New(cfg, stdin).WithStderr(&stderr).Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := stderr.String(); got != "using rows at \"items\" (--root items)\n" {
+		t.Fatalf("expected a single hint, got %q", got)
+	}
+}
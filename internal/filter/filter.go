@@ -1,13 +1,16 @@
 package filter
 
 import (
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/sriharip316/tablo/internal/flatten"
+	"github.com/sriharip316/tablo/internal/timefmt"
 )
 
 var (
@@ -96,7 +99,7 @@ func ParseCondition(expr string) (Condition, error) {
 	}
 
 	for _, opDef := range operators {
-		if idx := strings.Index(expr, opDef.str); idx > 0 {
+		if idx := indexOperator(expr, opDef.str); idx > 0 {
 			path := strings.TrimSpace(expr[:idx])
 			value := strings.TrimSpace(expr[idx+len(opDef.str):])
 
@@ -127,6 +130,23 @@ func ParseCondition(expr string) (Condition, error) {
 	return Condition{}, fmt.Errorf("invalid filter expression %q: no valid operator found", expr)
 }
 
+// indexOperator returns the index of the first op in expr outside of the
+// quoted path segments, such as labels."a=b", that precede the value.
+func indexOperator(expr, op string) int {
+	quoted := false
+	for i := 0; i < len(expr); i++ {
+		switch {
+		case quoted && expr[i] == '\\':
+			i++
+		case expr[i] == '"':
+			quoted = !quoted
+		case !quoted && strings.HasPrefix(expr[i:], op):
+			return i
+		}
+	}
+	return -1
+}
+
 // ParseConditions parses multiple filter condition strings
 func ParseConditions(exprs []string) ([]Condition, error) {
 	conditions := make([]Condition, 0, len(exprs))
@@ -157,15 +177,15 @@ func (f *Filter) Apply(rows []flatten.FlatKV) []flatten.FlatKV {
 
 	filtered := make([]flatten.FlatKV, 0, len(rows))
 	for _, row := range rows {
-		if f.matchesRow(row) {
+		if f.Match(row) {
 			filtered = append(filtered, row)
 		}
 	}
 	return filtered
 }
 
-// matchesRow checks if a row matches all filter conditions (AND logic)
-func (f *Filter) matchesRow(row flatten.FlatKV) bool {
+// Match checks if a row matches all filter conditions (AND logic)
+func (f *Filter) Match(row flatten.FlatKV) bool {
 	for _, condition := range f.Conditions {
 		if !f.matchesCondition(row, condition) {
 			return false
@@ -291,6 +311,8 @@ func (f *Filter) valueToString(value any) string {
 		return strconv.FormatFloat(float64(v), 'f', -1, 32)
 	case float64:
 		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return timefmt.Format(v)
 	default:
 		return fmt.Sprintf("%v", v)
 	}
@@ -301,7 +323,7 @@ func (f *Filter) isNumeric(value any) bool {
 	switch value.(type) {
 	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
 		return true
-	case float32, float64:
+	case float32, float64, json.Number:
 		return true
 	default:
 		return false
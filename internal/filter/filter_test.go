@@ -2,6 +2,7 @@ package filter
 
 import (
 	"testing"
+	"time"
 
 	"github.com/sriharip316/tablo/internal/flatten"
 )
@@ -19,6 +20,11 @@ func TestParseCondition(t *testing.T) {
 			expr: "name=John",
 			want: Condition{Path: "name", Operator: OpEqual, Value: "John"},
 		},
+		{
+			name: "operator inside quoted path segment",
+			expr: `labels."a=b">2`,
+			want: Condition{Path: `labels."a=b"`, Operator: OpGreaterThan, Value: "2"},
+		},
 		{
 			name: "not equal",
 			expr: "status!=active",
@@ -580,6 +586,51 @@ func TestFilter_Apply_Coverage(t *testing.T) {
 	}
 }
 
+func TestFilter_Match(t *testing.T) {
+	conditions, err := ParseConditions([]string{"age>25", "name~o"})
+	if err != nil {
+		t.Fatalf("parse conditions: %v", err)
+	}
+	f := NewFilter(conditions)
+	if !f.Match(flatten.FlatKV{"name": "John", "age": 30}) {
+		t.Error("expected row to match")
+	}
+	if f.Match(flatten.FlatKV{"name": "Jane", "age": 30}) {
+		t.Error("expected row not to match")
+	}
+	if !NewFilter(nil).Match(flatten.FlatKV{"name": "any"}) {
+		t.Error("filter without conditions should match every row")
+	}
+}
+
+func TestFilter_MatchDates(t *testing.T) {
+	row := flatten.FlatKV{
+		"joined": time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		"seen":   time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC),
+	}
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"joined=2024-03-01", true},
+		{"joined!=2024-03-01", false},
+		{"joined>2024-02-28", true},
+		{"joined<2024-02-28", false},
+		{"joined~2024-03", true},
+		{"seen=2024-03-01T09:30:00Z", true},
+		{"seen>=2024-03-01", true},
+	}
+	for _, tt := range tests {
+		conditions, err := ParseConditions([]string{tt.expr})
+		if err != nil {
+			t.Fatalf("parse %s: %v", tt.expr, err)
+		}
+		if got := NewFilter(conditions).Match(row); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
 // Helper functions
 func containsString(s, substr string) bool {
 	return len(s) >= len(substr) && findSubstring(s, substr) >= 0
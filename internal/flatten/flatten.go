@@ -2,16 +2,23 @@ package flatten
 
 import (
 	"encoding/json"
+	"maps"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/sriharip316/tablo/internal/keypath"
+	"github.com/sriharip316/tablo/internal/selectors"
 )
 
 type Options struct {
 	Enabled            bool
-	MaxDepth           int // -1 unlimited
-	DivePaths          []string
+	MaxDepth           int              // -1 unlimited
+	DivePaths          []selectors.Expr // compiled --dive-path patterns; nil dives everywhere
 	FlattenSimpleArray bool
+	Explode            []string // paths of arrays to emit one row per element of
+	Separator          string   // joins key segments; "" means "."
 }
 
 type FlatKV map[string]any
@@ -26,21 +33,37 @@ func (f FlatKV) Keys() []string {
 }
 
 // FlattenObject flattens an object (map[string]any) respecting Options.
+// Keys holding the separator are quoted, as in labels."app.kubernetes.io/name",
+// so every column has a single path.
 func FlattenObject(obj any, o Options) FlatKV {
 	out := make(FlatKV)
-	if !o.Enabled {
-		// do not dive; stringify composite
-		switch m := obj.(type) {
-		case map[string]any:
-			for k, v := range m {
-				out[k] = maybeStringify(v, o)
-			}
-		default:
+	sep := o.Separator
+	if sep == "" {
+		sep = keypath.DefaultSeparator
+	}
+	join := func(prefix, k string) string {
+		if prefix == "" {
+			return keypath.Quote(k, sep)
+		}
+		return prefix + sep + keypath.Quote(k, sep)
+	}
+	m, ok := obj.(map[string]any)
+	if !ok {
+		if !o.Enabled {
 			// if not map, return key VALUE mapping
 			out["VALUE"] = maybeStringify(obj, o)
 		}
 		return out
 	}
+	explode := explodePaths(o.Explode, sep)
+	if !o.Enabled && len(explode) == 0 {
+		// do not dive; stringify composite. Keys are kept as they are, since
+		// no path was built from them.
+		for k, v := range m {
+			out[k] = maybeStringify(v, o)
+		}
+		return out
+	}
 	var walk func(prefix string, v any, depth int)
 	walk = func(prefix string, v any, depth int) {
 		if o.MaxDepth >= 0 && depth > o.MaxDepth {
@@ -50,22 +73,11 @@ func FlattenObject(obj any, o Options) FlatKV {
 		switch vv := v.(type) {
 		case map[string]any:
 			for k, val := range vv {
-				p := k
-				if prefix != "" {
-					p = prefix + "." + k
-				}
-				walk(p, val, depth+1)
+				walk(join(prefix, k), val, depth+1)
 			}
 		case []any:
 			// only flatten arrays of objects
-			allObj := true
-			for _, it := range vv {
-				if _, ok := it.(map[string]any); !ok {
-					allObj = false
-					break
-				}
-			}
-			if !allObj {
+			if !isObjectArray(vv) {
 				if o.FlattenSimpleArray {
 					out[prefix] = simpleArrayToCSV(vv)
 				} else {
@@ -74,7 +86,7 @@ func FlattenObject(obj any, o Options) FlatKV {
 				return
 			}
 			for i, it := range vv {
-				p := prefix + "." + strconv.Itoa(i)
+				p := prefix + sep + strconv.Itoa(i)
 				walk(p, it, depth+1)
 			}
 		default:
@@ -85,47 +97,98 @@ func FlattenObject(obj any, o Options) FlatKV {
 			}
 		}
 	}
-	if m, ok := obj.(map[string]any); ok {
-		// if DivePaths specified, only dive into those top-level keys
-		allow := map[string]struct{}{}
-		if len(o.DivePaths) > 0 {
-			for _, p := range o.DivePaths {
-				allow[p] = struct{}{}
+	var dive []selectors.Expr
+	if o.Enabled {
+		dive = o.DivePaths
+		if len(dive) == 0 {
+			for k, v := range m {
+				walk(join("", k), v, 1)
 			}
+			return out
 		}
-		for k, v := range m {
-			if len(allow) > 0 {
-				if _, ok := allow[k]; ok {
-					walk(k, v, 1)
-				} else {
-					// keep as is; if scalar, keep value; else stringify or CSV for simple arrays
-					switch vv := v.(type) {
-					case []any:
-						if o.FlattenSimpleArray {
-							out[k] = simpleArrayToCSV(vv)
-						} else {
-							out[k] = stringify(vv)
-						}
-					case map[string]any:
-						out[k] = stringify(vv)
-					default:
-						out[k] = vv
+	}
+
+	// otherwise dive only into the branches matching DivePaths, and into
+	// exploded elements; the objects leading to them are flattened too and
+	// other values are kept as they are
+	var branch func(prefix string, segs []string, v any, depth int)
+	branch = func(prefix string, segs []string, v any, depth int) {
+		covers, leads := false, false
+		for _, ex := range dive {
+			covers = covers || ex.Covers(segs)
+			leads = leads || ex.Leads(segs)
+		}
+		if covers {
+			walk(prefix, v, depth)
+			return
+		}
+		if leads && o.MaxDepth >= 0 && depth > o.MaxDepth {
+			leads = false
+		}
+		for _, path := range explode {
+			leads = leads || isPrefix(segs, path)
+		}
+		if leads {
+			switch vv := v.(type) {
+			case map[string]any:
+				for k, val := range vv {
+					branch(join(prefix, k), append(segs[:len(segs):len(segs)], k), val, depth+1)
+				}
+				return
+			case []any:
+				if isObjectArray(vv) {
+					for i, it := range vv {
+						k := strconv.Itoa(i)
+						branch(prefix+sep+k, append(segs[:len(segs):len(segs)], k), it, depth+1)
 					}
+					return
 				}
-			} else {
-				walk(k, v, 1)
 			}
 		}
+		// keep as is; if scalar, keep value; else stringify or CSV for simple arrays
+		out[prefix] = maybeStringify(v, o)
+	}
+	for k, v := range m {
+		branch(join("", k), []string{k}, v, 1)
 	}
 	return out
 }
 
+// explodePaths splits explode paths into segments, skipping invalid ones.
+func explodePaths(paths []string, sep string) [][]string {
+	var out [][]string
+	for _, p := range paths {
+		if segs, err := keypath.Split(p, sep); err == nil {
+			out = append(out, segs)
+		}
+	}
+	return out
+}
+
+// isPrefix reports whether segs is path or leads to it.
+func isPrefix(segs, path []string) bool {
+	return len(segs) <= len(path) && slices.Equal(segs, path[:len(segs)])
+}
+
+func isObjectArray(arr []any) bool {
+	for _, it := range arr {
+		if _, ok := it.(map[string]any); !ok {
+			return false
+		}
+	}
+	return true
+}
+
 // FlattenRows flattens an array of object-like values into rows of FlatKV.
+// Objects are first exploded along Options.Explode, so they may yield
+// several rows.
 func FlattenRows(arr []any, o Options) []FlatKV {
 	rows := make([]FlatKV, 0, len(arr))
 	for _, it := range arr {
 		if m, ok := it.(map[string]any); ok {
-			rows = append(rows, FlattenObject(m, o))
+			for _, row := range Explode(m, o.Explode, o.Separator) {
+				rows = append(rows, FlattenObject(row, o))
+			}
 		} else {
 			rows = append(rows, FlatKV{"VALUE": maybeStringify(it, o)})
 		}
@@ -133,6 +196,72 @@ func FlattenRows(arr []any, o Options) []FlatKV {
 	return rows
 }
 
+// Explode returns a copy of obj for each element of the array at each of
+// paths in turn, like SQL's UNNEST: the element takes the place of the
+// array, and FlattenObject then turns the fields of an object element into
+// columns such as items.sku. Parent fields are repeated in every copy. An
+// empty array yields one copy without the key, so the element columns of
+// that row are null; objects without an array at the path are returned
+// unchanged.
+func Explode(obj map[string]any, paths []string, sep string) []map[string]any {
+	rows := []map[string]any{obj}
+	for _, path := range explodePaths(paths, sep) {
+		next := make([]map[string]any, 0, len(rows))
+		for _, row := range rows {
+			next = append(next, explode(row, path)...)
+		}
+		rows = next
+	}
+	return rows
+}
+
+func explode(obj map[string]any, path []string) []map[string]any {
+	var v any = obj
+	for _, k := range path {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return []map[string]any{obj}
+		}
+		v = m[k]
+	}
+	arr, ok := v.([]any)
+	if !ok {
+		return []map[string]any{obj}
+	}
+	if len(arr) == 0 {
+		return []map[string]any{remove(obj, path)}
+	}
+	rows := make([]map[string]any, len(arr))
+	for i, el := range arr {
+		rows[i] = replace(obj, path, el)
+	}
+	return rows
+}
+
+// replace returns a copy of obj with the value at path set to v, copying
+// only the objects along the path.
+func replace(obj map[string]any, path []string, v any) map[string]any {
+	out := maps.Clone(obj)
+	if len(path) == 1 {
+		out[path[0]] = v
+	} else {
+		out[path[0]] = replace(obj[path[0]].(map[string]any), path[1:], v)
+	}
+	return out
+}
+
+// remove returns a copy of obj without the key at path, copying only the
+// objects along the path.
+func remove(obj map[string]any, path []string) map[string]any {
+	out := maps.Clone(obj)
+	if len(path) == 1 {
+		delete(out, path[0])
+	} else {
+		out[path[0]] = remove(obj[path[0]].(map[string]any), path[1:])
+	}
+	return out
+}
+
 func maybeStringify(v any, o Options) any {
 	switch vv := v.(type) {
 	case map[string]any:
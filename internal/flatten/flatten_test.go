@@ -5,8 +5,20 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/sriharip316/tablo/internal/selectors"
 )
 
+// compileDive compiles dive paths as the app does for --dive-path.
+func compileDive(t *testing.T, paths ...string) []selectors.Expr {
+	t.Helper()
+	exprs, err := selectors.CompileMany(paths)
+	if err != nil {
+		t.Fatalf("compile %v: %v", paths, err)
+	}
+	return exprs
+}
+
 func TestFlattenSimple(t *testing.T) {
 	m := map[string]any{"a": 1, "b": map[string]any{"c": 2}}
 	kv := FlattenObject(m, Options{Enabled: true, MaxDepth: -1})
@@ -27,6 +39,11 @@ func TestFlatten_Disabled(t *testing.T) {
 	if s, ok := kv["b"].(string); !ok || s == "" {
 		t.Fatalf("expected stringified b, got %T %v", kv["b"], kv["b"])
 	}
+	// keys are not quoted when no path is built from them
+	kv = FlattenObject(map[string]any{"user.name": "bob"}, Options{Enabled: false})
+	if kv["user.name"] != "bob" {
+		t.Fatalf("expected literal key user.name, got %v", kv)
+	}
 }
 
 func TestFlatten_WithDepthAndArrays(t *testing.T) {
@@ -143,7 +160,7 @@ func TestFlatten_DivePathsSelective(t *testing.T) {
 
 	kv := FlattenObject(obj, Options{
 		Enabled:            true,
-		DivePaths:          []string{"a"},
+		DivePaths:          compileDive(t, "a"),
 		MaxDepth:           -1,
 		FlattenSimpleArray: true,
 	})
@@ -169,6 +186,63 @@ func TestFlatten_DivePathsSelective(t *testing.T) {
 }
 
 // Test MaxDepth exact edge: when depth limit is reached, nested content is stringified.
+func TestFlatten_DivePathsNestedAndGlob(t *testing.T) {
+	manifest := map[string]any{
+		"kind": "Deployment",
+		"metadata": map[string]any{
+			"name":        "web",
+			"labels":      map[string]any{"app": "web", "tier": "front"},
+			"annotations": map[string]any{"note": "x"},
+		},
+		"spec": map[string]any{
+			"replicas": 2,
+			"selector": map[string]any{"app": "web"},
+			"template": map[string]any{
+				"metadata":   map[string]any{"labels": map[string]any{"app": "web"}},
+				"containers": []any{map[string]any{"name": "nginx"}},
+			},
+		},
+	}
+	tests := []struct {
+		name  string
+		paths []string
+		want  FlatKV
+	}{
+		{"nested", []string{"metadata.labels"}, FlatKV{
+			"kind":                 "Deployment",
+			"metadata.name":        "web",
+			"metadata.labels.app":  "web",
+			"metadata.labels.tier": "front",
+			"metadata.annotations": `{"note":"x"}`,
+			"spec":                 stringify(manifest["spec"]),
+		}},
+		{"glob", []string{"spec.template.*"}, FlatKV{
+			"kind":                              "Deployment",
+			"metadata":                          stringify(manifest["metadata"]),
+			"spec.replicas":                     2,
+			"spec.selector":                     `{"app":"web"}`,
+			"spec.template.metadata.labels.app": "web",
+			"spec.template.containers.0.name":   "nginx",
+		}},
+		{"glob through array indices", []string{"spec.template.containers.*.name"}, FlatKV{
+			"kind":                            "Deployment",
+			"metadata":                        stringify(manifest["metadata"]),
+			"spec.replicas":                   2,
+			"spec.selector":                   `{"app":"web"}`,
+			"spec.template.metadata":          `{"labels":{"app":"web"}}`,
+			"spec.template.containers.0.name": "nginx",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FlattenObject(manifest, Options{Enabled: true, MaxDepth: -1, DivePaths: compileDive(t, tt.paths...)})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestFlatten_MaxDepthEdge(t *testing.T) {
 	obj := map[string]any{
 		"top": map[string]any{
@@ -225,7 +299,7 @@ func TestFlatten_DivePathsIgnoredWhenDisabled(t *testing.T) {
 	obj := map[string]any{
 		"a": map[string]any{"b": 1},
 	}
-	kv := FlattenObject(obj, Options{Enabled: false, DivePaths: []string{"a"}})
+	kv := FlattenObject(obj, Options{Enabled: false, DivePaths: compileDive(t, "a")})
 	if _, ok := kv["a.b"]; ok {
 		t.Fatalf("unexpected flattened key when disabled: %+v", kv)
 	}
@@ -247,7 +321,7 @@ func TestFlatten_DivePaths_ArrayOfObjects(t *testing.T) {
 		},
 	}
 
-	kv := FlattenObject(obj, Options{Enabled: true, DivePaths: []string{"dive"}, MaxDepth: -1})
+	kv := FlattenObject(obj, Options{Enabled: true, DivePaths: compileDive(t, "dive"), MaxDepth: -1})
 	// Expect flattened indices for dive.*
 	if kv["dive.0.y"] != 3 || kv["dive.1.y"] != 4 {
 		t.Fatalf("missing flattened dive indices: %+v", kv)
@@ -324,3 +398,114 @@ func TestMaybeStringify(t *testing.T) {
 		})
 	}
 }
+
+func TestExplode(t *testing.T) {
+	order := func() map[string]any {
+		return map[string]any{
+			"id":    1,
+			"items": []any{map[string]any{"sku": "A", "serials": []any{"s1", "s2"}}, map[string]any{"sku": "B", "serials": []any{}}},
+			"ship":  map[string]any{"city": "Oslo", "tags": []any{"x", "y"}},
+		}
+	}
+	itemA := func(serials any) map[string]any { return map[string]any{"sku": "A", "serials": serials} }
+	tests := []struct {
+		name  string
+		paths []string
+		want  []map[string]any
+	}{
+		{"no paths", nil, []map[string]any{order()}},
+		{"missing path", []string{"lines"}, []map[string]any{order()}},
+		{"objects", []string{"items"}, []map[string]any{
+			{"id": 1, "items": itemA([]any{"s1", "s2"}), "ship": order()["ship"]},
+			{"id": 1, "items": map[string]any{"sku": "B", "serials": []any{}}, "ship": order()["ship"]},
+		}},
+		{"nested primitives", []string{"ship.tags"}, []map[string]any{
+			{"id": 1, "items": order()["items"], "ship": map[string]any{"city": "Oslo", "tags": "x"}},
+			{"id": 1, "items": order()["items"], "ship": map[string]any{"city": "Oslo", "tags": "y"}},
+		}},
+		{"within an exploded element, empty array dropped", []string{"items", "items.serials"}, []map[string]any{
+			{"id": 1, "items": itemA("s1"), "ship": order()["ship"]},
+			{"id": 1, "items": itemA("s2"), "ship": order()["ship"]},
+			{"id": 1, "items": map[string]any{"sku": "B"}, "ship": order()["ship"]},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := order()
+			got := Explode(obj, tt.paths, "")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(obj, order()) {
+				t.Fatalf("input was modified: %v", obj)
+			}
+		})
+	}
+}
+
+func TestFlattenRows_Explode(t *testing.T) {
+	arr := []any{
+		map[string]any{"id": 1, "items": []any{map[string]any{"sku": "A"}, map[string]any{"sku": "B"}}},
+		"scalar",
+	}
+	rows := FlattenRows(arr, Options{Explode: []string{"items"}})
+	want := []FlatKV{{"id": 1, "items.sku": "A"}, {"id": 1, "items.sku": "B"}, {"VALUE": "scalar"}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Fatalf("got %v, want %v", rows, want)
+	}
+
+	// element fields are columns, nested values are kept without --dive
+	order := map[string]any{
+		"id":    1,
+		"order": map[string]any{"note": "x", "lines": []any{map[string]any{"sku": "A", "dims": map[string]any{"w": 1}}}},
+	}
+	rows = FlattenRows([]any{order}, Options{Explode: []string{"order.lines"}})
+	want = []FlatKV{{"id": 1, "order.note": "x", "order.lines.sku": "A", "order.lines.dims": `{"w":1}`}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Fatalf("got %v, want %v", rows, want)
+	}
+
+	// an empty array adds no column of its own
+	arr = []any{
+		map[string]any{"id": 1, "items": []any{map[string]any{"sku": "A"}}},
+		map[string]any{"id": 2, "items": []any{}},
+		map[string]any{"id": 3, "order": map[string]any{"lines": []any{}}},
+	}
+	rows = FlattenRows(arr, Options{Explode: []string{"items", "order.lines"}})
+	headers := map[string]bool{}
+	for _, r := range rows {
+		for k := range r {
+			headers[k] = true
+		}
+	}
+	if want := map[string]bool{"id": true, "items.sku": true}; !reflect.DeepEqual(headers, want) {
+		t.Fatalf("got headers %v, want %v", headers, want)
+	}
+}
+
+func TestFlatten_QuotedKeysAndSeparator(t *testing.T) {
+	obj := map[string]any{
+		"metadata": map[string]any{"labels": map[string]any{"app.kubernetes.io/name": "web"}},
+		"1.0":      "old",
+		"items":    []any{map[string]any{"a/b": 1}},
+	}
+	tests := []struct {
+		sep  string
+		want FlatKV
+	}{
+		{"", FlatKV{`metadata.labels."app.kubernetes.io/name"`: "web", `"1.0"`: "old", "items.0.a/b": 1}},
+		{"/", FlatKV{`metadata/labels/"app.kubernetes.io/name"`: "web", "1.0": "old", `items/0/"a/b"`: 1}},
+		{"__", FlatKV{"metadata__labels__app.kubernetes.io/name": "web", "1.0": "old", "items__0__a/b": 1}},
+	}
+	for _, tt := range tests {
+		got := FlattenObject(obj, Options{Enabled: true, MaxDepth: -1, Separator: tt.sep})
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Fatalf("sep %q: got %v, want %v", tt.sep, got, tt.want)
+		}
+	}
+
+	got := FlattenObject(obj, Options{Enabled: true, MaxDepth: -1, DivePaths: compileDive(t, `metadata.labels."app.kubernetes.io/name"`)})
+	if got[`metadata.labels."app.kubernetes.io/name"`] != "web" {
+		t.Fatalf("quoted dive path not followed: %v", got)
+	}
+}
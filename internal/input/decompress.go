@@ -0,0 +1,107 @@
+package input
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+// Compression identifies a compressed stream encoding.
+type Compression string
+
+const (
+	None  Compression = ""
+	Gzip  Compression = "gzip"
+	Zstd  Compression = "zstd"
+	Bzip2 Compression = "bzip2"
+	XZ    Compression = "xz"
+)
+
+var magics = []struct {
+	c     Compression
+	magic []byte
+}{
+	{Gzip, []byte{0x1f, 0x8b}},
+	{Zstd, []byte{0x28, 0xb5, 0x2f, 0xfd}},
+	{XZ, []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}},
+}
+
+// bzip2 streams start with "BZh", a block size digit and then the magic of
+// either a compressed block or the end of stream. Checking all of it avoids
+// mistaking plain text beginning with "BZh" for bzip2.
+var (
+	bzip2BlockMagic = []byte{0x31, 0x41, 0x59, 0x26, 0x53, 0x59}
+	bzip2EOSMagic   = []byte{0x17, 0x72, 0x45, 0x38, 0x50, 0x90}
+)
+
+// sniffSize is the number of leading bytes needed by DetectCompression.
+const sniffSize = 10
+
+// DetectCompression sniffs the magic bytes at the start of header.
+func DetectCompression(header []byte) Compression {
+	for _, m := range magics {
+		if bytes.HasPrefix(header, m.magic) {
+			return m.c
+		}
+	}
+	if len(header) >= sniffSize && bytes.HasPrefix(header, []byte("BZh")) &&
+		header[3] >= '1' && header[3] <= '9' &&
+		(bytes.Equal(header[4:10], bzip2BlockMagic) || bytes.Equal(header[4:10], bzip2EOSMagic)) {
+		return Bzip2
+	}
+	return None
+}
+
+// Decompress wraps rc with a decoder chosen by sniffing its magic bytes.
+// Input without a known signature is passed through unchanged. Closing the
+// result also closes rc.
+func Decompress(rc io.ReadCloser) (io.ReadCloser, error) {
+	br := bufio.NewReader(rc)
+	header, _ := br.Peek(sniffSize)
+
+	var dec io.Reader
+	var closeDec func()
+	switch DetectCompression(header) {
+	case Gzip:
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		dec, closeDec = gz, func() { _ = gz.Close() }
+	case Zstd:
+		zr, err := zstd.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		dec, closeDec = zr, zr.Close
+	case Bzip2:
+		dec = bzip2.NewReader(br)
+	case XZ:
+		xr, err := xz.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		dec = xr
+	default:
+		dec = br
+	}
+	return &decompressReader{Reader: dec, closeDec: closeDec, src: rc}, nil
+}
+
+type decompressReader struct {
+	io.Reader
+	closeDec func()
+	src      io.Closer
+}
+
+func (d *decompressReader) Close() error {
+	if d.closeDec != nil {
+		d.closeDec()
+	}
+	return d.src.Close()
+}
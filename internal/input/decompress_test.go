@@ -0,0 +1,125 @@
+package input
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ulikunitz/xz"
+)
+
+// bzip2Fixture is "name\nAlice\n" compressed with bzip2 (no stdlib encoder).
+var bzip2Fixture = []byte{
+	0x42, 0x5a, 0x68, 0x39, 0x31, 0x41, 0x59, 0x26, 0x53, 0x59, 0xaa, 0x10, 0x25, 0x80, 0x00, 0x00,
+	0x05, 0x45, 0x00, 0x00, 0x10, 0x20, 0x00, 0x2a, 0x27, 0x20, 0x00, 0x31, 0x06, 0x4c, 0x41, 0x00,
+	0xd3, 0x6a, 0x6c, 0x51, 0xc3, 0x18, 0xfc, 0x5d, 0xc9, 0x14, 0xe1, 0x42, 0x42, 0xa8, 0x40, 0x96,
+	0x00,
+}
+
+func compress(t *testing.T, c Compression, data string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	var err error
+	switch c {
+	case Gzip:
+		w = gzip.NewWriter(&buf)
+	case Zstd:
+		w, err = zstd.NewWriter(&buf)
+	case XZ:
+		w, err = xz.NewWriter(&buf)
+	case Bzip2:
+		return bzip2Fixture
+	default:
+		return []byte(data)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.WriteString(w, data); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecompress_Formats(t *testing.T) {
+	for _, c := range []Compression{None, Gzip, Zstd, Bzip2, XZ} {
+		t.Run(string(c), func(t *testing.T) {
+			data := compress(t, c, "name\nAlice\n")
+			if got := DetectCompression(data); got != c {
+				t.Fatalf("detected %q, want %q", got, c)
+			}
+			r := NewReader("", "", bytes.NewReader(data), 100)
+			b, err := r.Read()
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if string(b) != "name\nAlice\n" {
+				t.Fatalf("got %q", string(b))
+			}
+		})
+	}
+}
+
+func TestDecompress_File(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "data.json.gz")
+	if err := os.WriteFile(p, compress(t, Gzip, `{"a":1}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	b, err := NewReader("", p, nil, 100).Read()
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if string(b) != `{"a":1}` {
+		t.Fatalf("got %q", string(b))
+	}
+}
+
+func TestDecompress_LimitAppliesToDecompressedSize(t *testing.T) {
+	data := compress(t, Gzip, strings.Repeat("a", 1000))
+	if len(data) >= 100 {
+		t.Fatalf("fixture should compress below the limit, got %d bytes", len(data))
+	}
+	_, err := NewReader("", "", bytes.NewReader(data), 100).Read()
+	if !errors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("expected ErrLimitExceeded, got %v", err)
+	}
+}
+
+func TestDecompress_OpenStreamsPastLimit(t *testing.T) {
+	data := compress(t, Gzip, strings.Repeat("{\"a\":1}\n", 100))
+	rc, err := NewReader("", "", bytes.NewReader(data), 100).Open()
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	defer func() { _ = rc.Close() }()
+	b, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(b) != 800 {
+		t.Fatalf("read %d bytes, want 800", len(b))
+	}
+}
+
+func TestDecompress_PlainTextStartingWithBZh(t *testing.T) {
+	if got := DetectCompression([]byte("BZh9 is not bzip2")); got != None {
+		t.Fatalf("detected %q for plain text", got)
+	}
+}
+
+func TestDecompress_CorruptStream(t *testing.T) {
+	_, err := NewReader("", "", bytes.NewReader([]byte{0x1f, 0x8b, 0x00}), 100).Read()
+	if err == nil {
+		t.Fatal("expected error for truncated gzip header")
+	}
+}
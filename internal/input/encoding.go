@@ -0,0 +1,65 @@
+package input
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/htmlindex"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+var (
+	bomUTF8    = []byte{0xef, 0xbb, 0xbf}
+	bomUTF16LE = []byte{0xff, 0xfe}
+	bomUTF16BE = []byte{0xfe, 0xff}
+)
+
+// LookupEncoding resolves a character encoding by its WHATWG label, such as
+// "utf-16le", "latin1", "windows-1252" or "shift_jis". An empty name means
+// UTF-8.
+func LookupEncoding(name string) (encoding.Encoding, error) {
+	if name == "" {
+		return unicode.UTF8, nil
+	}
+	enc, err := htmlindex.Get(strings.TrimSpace(name))
+	if err != nil {
+		return nil, fmt.Errorf("unsupported encoding %q", name)
+	}
+	return enc, nil
+}
+
+// Decode transcodes r to UTF-8. A UTF-8 or UTF-16 byte order mark takes
+// precedence and is stripped; otherwise the named encoding is used. UTF-8
+// input without a BOM is passed through untouched. The BOM is sniffed on the
+// first read, so Decode itself never blocks.
+func Decode(r io.Reader, name string) (io.Reader, error) {
+	enc, err := LookupEncoding(name)
+	if err != nil {
+		return nil, err
+	}
+	return &decodeReader{src: bufio.NewReader(r), enc: enc}, nil
+}
+
+type decodeReader struct {
+	src *bufio.Reader
+	enc encoding.Encoding
+	r   io.Reader
+}
+
+func (d *decodeReader) Read(p []byte) (int, error) {
+	if d.r == nil {
+		head, _ := d.src.Peek(3)
+		hasBOM := bytes.HasPrefix(head, bomUTF8) || bytes.HasPrefix(head, bomUTF16LE) || bytes.HasPrefix(head, bomUTF16BE)
+		if !hasBOM && d.enc == unicode.UTF8 {
+			d.r = d.src
+		} else {
+			d.r = transform.NewReader(d.src, unicode.BOMOverride(d.enc.NewDecoder()))
+		}
+	}
+	return d.r.Read(p)
+}
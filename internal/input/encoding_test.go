@@ -0,0 +1,79 @@
+package input
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       []byte
+		encoding string
+		want     string
+	}{
+		{"plain utf-8", []byte("name,city\n"), "", "name,city\n"},
+		{"utf-8 bom", []byte("\xef\xbb\xbfname\n"), "", "name\n"},
+		{"utf-16le bom", []byte("\xff\xfen\x00a\x00\n\x00"), "", "na\n"},
+		{"utf-16be bom", []byte("\xfe\xff\x00n\x00a\x00\n"), "", "na\n"},
+		{"bom overrides encoding", []byte("\xff\xfen\x00a\x00"), "windows-1252", "na"},
+		{"utf-16le without bom", []byte("n\x00a\x00"), "utf-16le", "na"},
+		{"windows-1252", []byte("caf\xe9 \x80"), "windows-1252", "café €"},
+		{"latin1", []byte("na\xefve"), "latin1", "naïve"},
+		{"shift_jis", []byte("\x93\xfa\x96\x7b"), "shift_jis", "日本"},
+		{"short input", []byte("a"), "", "a"},
+		{"empty", nil, "utf-16le", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Decode(strings.NewReader(string(tt.in)), tt.encoding)
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			b, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if string(b) != tt.want {
+				t.Fatalf("got %q, want %q", string(b), tt.want)
+			}
+		})
+	}
+}
+
+func TestLookupEncoding_Unsupported(t *testing.T) {
+	_, err := LookupEncoding("klingon")
+	if err == nil || !strings.Contains(err.Error(), "unsupported encoding") {
+		t.Fatalf("expected unsupported encoding error, got %v", err)
+	}
+}
+
+func TestReader_Open_Transcodes(t *testing.T) {
+	r := NewReader("", "", strings.NewReader("\xff\xfea\x00,\x00b\x00"), 0)
+	rc, err := r.Open()
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	defer func() { _ = rc.Close() }()
+	b, err := io.ReadAll(rc)
+	if err != nil || string(b) != "a,b" {
+		t.Fatalf("got %q, %v", string(b), err)
+	}
+}
+
+func TestReader_Open_SkipsBinary(t *testing.T) {
+	data := "PAR1\xff\xfe\x80"
+	r := NewReader("", "", strings.NewReader(data), 0).
+		WithEncoding("windows-1252").
+		WithBinary(func(head []byte) bool { return strings.HasPrefix(string(head), "PAR1") })
+	rc, err := r.Open()
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	defer func() { _ = rc.Close() }()
+	b, err := io.ReadAll(rc)
+	if err != nil || string(b) != data {
+		t.Fatalf("got %q, %v", string(b), err)
+	}
+}
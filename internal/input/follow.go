@@ -0,0 +1,62 @@
+package input
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/sriharip316/tablo/internal/sourcepath"
+)
+
+// OpenFollow opens the input like Open, except that reads at the end of a
+// file wait for appended data instead of returning io.EOF, polling every
+// interval, like tail -f. Stdin is returned as-is since its reads already
+// block until data arrives. Followed input is transcoded to UTF-8 but never
+// decompressed.
+func (r *Reader) OpenFollow(interval time.Duration) (io.ReadCloser, error) {
+	if sourcepath.IsURL(r.file) {
+		return nil, errors.New("cannot follow a URL")
+	}
+	if r.inStr == "" && r.file != "" {
+		f, err := os.Open(r.file)
+		if err != nil {
+			return nil, err
+		}
+		return r.decode(&followReader{f: f, interval: interval})
+	}
+	rc, err := r.openRaw()
+	if err != nil {
+		return nil, err
+	}
+	return r.decode(rc)
+}
+
+type followReader struct {
+	f        *os.File
+	interval time.Duration
+	closed   atomic.Bool
+}
+
+func (r *followReader) Read(p []byte) (int, error) {
+	for {
+		n, err := r.f.Read(p)
+		if n > 0 {
+			return n, nil
+		}
+		if r.closed.Load() {
+			return 0, io.EOF
+		}
+		if !errors.Is(err, io.EOF) {
+			return 0, err
+		}
+		time.Sleep(r.interval)
+	}
+}
+
+// Close stops following; a pending Read returns once it wakes up.
+func (r *followReader) Close() error {
+	r.closed.Store(true)
+	return r.f.Close()
+}
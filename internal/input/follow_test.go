@@ -0,0 +1,57 @@
+package input
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestOpenFollow_WaitsForAppendedData(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "growing.log")
+	if err := os.WriteFile(p, []byte("a\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	rc, err := NewReader("", p, nil, 0).OpenFollow(5 * time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	got := make(chan string, 1)
+	go func() {
+		buf := make([]byte, 4)
+		n, _ := io.ReadFull(rc, buf)
+		got <- string(buf[:n])
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	f, err := os.OpenFile(p, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("b\n")
+	_ = f.Close()
+
+	select {
+	case s := <-got:
+		if s != "a\nb\n" {
+			t.Fatalf("got %q", s)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("read did not pick up appended data")
+	}
+
+	if err := rc.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if _, err := rc.Read(make([]byte, 1)); err != io.EOF {
+		t.Fatalf("expected io.EOF after close, got %v", err)
+	}
+}
+
+func TestOpenFollow_URLUnsupported(t *testing.T) {
+	if _, err := NewReader("", "https://example.com/a.jsonl", nil, 0).OpenFollow(time.Millisecond); err == nil {
+		t.Fatal("expected error for URL")
+	}
+}
@@ -0,0 +1,65 @@
+package input
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// HTTPOptions configures how URL inputs are fetched.
+type HTTPOptions struct {
+	Headers []string      // "Name: value" request headers
+	Timeout time.Duration // whole-request timeout; 0 = none
+}
+
+// HTTPStatusError reports a non-2xx response for a URL input.
+type HTTPStatusError struct {
+	URL        string
+	StatusCode int
+	Status     string
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("GET %s: %s", e.URL, e.Status)
+}
+
+// ParseHeaders converts "Name: value" strings into an http.Header.
+func ParseHeaders(headers []string) (http.Header, error) {
+	h := make(http.Header, len(headers))
+	for _, raw := range headers {
+		name, value, ok := strings.Cut(raw, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid header %q: expected 'Name: value'", raw)
+		}
+		h.Add(name, strings.TrimSpace(value))
+	}
+	return h, nil
+}
+
+// fetch issues a GET request for rawURL and returns the response body along
+// with its Content-Type.
+func fetch(rawURL string, o HTTPOptions) (io.ReadCloser, string, error) {
+	headers, err := ParseHeaders(o.Headers)
+	if err != nil {
+		return nil, "", err
+	}
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header = headers
+
+	client := &http.Client{Timeout: o.Timeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		_ = resp.Body.Close()
+		return nil, "", &HTTPStatusError{URL: rawURL, StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+	return resp.Body, resp.Header.Get("Content-Type"), nil
+}
@@ -0,0 +1,76 @@
+package input
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReader_URL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		_, _ = io.WriteString(w, "{\"a\":1}\n")
+	}))
+	defer srv.Close()
+
+	r := NewReader("", srv.URL+"/events", nil, 100).WithHTTP(HTTPOptions{
+		Headers: []string{"Authorization: Bearer secret"},
+	})
+	b, err := r.Read()
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if string(b) != "{\"a\":1}\n" {
+		t.Fatalf("got %q", string(b))
+	}
+	if r.ContentType() != "application/x-ndjson" {
+		t.Fatalf("content type: got %q", r.ContentType())
+	}
+}
+
+func TestReader_URLStatusError(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	_, err := NewReader("", srv.URL+"/missing.json", nil, 100).Read()
+	var statusErr *HTTPStatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected HTTPStatusError 404, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "404 Not Found") {
+		t.Fatalf("unexpected message: %v", err)
+	}
+}
+
+func TestReader_URLTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	r := NewReader("", srv.URL, nil, 100).WithHTTP(HTTPOptions{Timeout: 20 * time.Millisecond})
+	if _, err := r.Read(); err == nil {
+		t.Fatal("expected timeout error")
+	}
+}
+
+func TestParseHeaders(t *testing.T) {
+	h, err := ParseHeaders([]string{"Accept: text/csv", "X-Trace:  abc "})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if h.Get("Accept") != "text/csv" || h.Get("X-Trace") != "abc" {
+		t.Fatalf("unexpected headers: %v", h)
+	}
+	if _, err := ParseHeaders([]string{"no-colon"}); err == nil {
+		t.Fatal("expected error for header without colon")
+	}
+}
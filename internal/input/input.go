@@ -1,9 +1,15 @@
 package input
 
 import (
+	"bufio"
 	"errors"
+	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sriharip316/tablo/internal/sourcepath"
 )
 
 var ErrLimitExceeded = errors.New("input size exceeds limit")
@@ -13,8 +19,17 @@ type Reader struct {
 	file     string
 	stdin    io.Reader
 	maxBytes int64
+
+	http        HTTPOptions
+	encoding    string
+	isBinary    func(head []byte) bool
+	contentType string
 }
 
+// binarySniffSize is how much of the decompressed input is passed to the
+// check set with WithBinary.
+const binarySniffSize = 64 * 1024
+
 func NewReader(inStr, file string, stdin io.Reader, maxBytes int64) *Reader {
 	return &Reader{
 		inStr:    inStr,
@@ -24,51 +39,154 @@ func NewReader(inStr, file string, stdin io.Reader, maxBytes int64) *Reader {
 	}
 }
 
+// WithHTTP sets the options used when file is an http(s) URL.
+func (r *Reader) WithHTTP(o HTTPOptions) *Reader {
+	r.http = o
+	return r
+}
+
+// WithEncoding sets the character encoding the input is transcoded from.
+// A byte order mark in the input overrides it.
+func (r *Reader) WithEncoding(name string) *Reader {
+	r.encoding = name
+	return r
+}
+
+// WithBinary sets a check for binary input, such as a spreadsheet or a
+// Parquet file, given the leading bytes of the decompressed data. Binary
+// input is never transcoded, whatever the encoding.
+func (r *Reader) WithBinary(isBinary func(head []byte) bool) *Reader {
+	r.isBinary = isBinary
+	return r
+}
+
+// ContentType returns the Content-Type of a fetched URL input. It is empty
+// until Open has been called, and for non-URL inputs.
+func (r *Reader) ContentType() string {
+	return r.contentType
+}
+
+// Read returns the whole (decompressed) input, failing with ErrLimitExceeded
+// when it is larger than the configured limit.
 func (r *Reader) Read() ([]byte, error) {
 	if r.inStr != "" {
 		if r.maxBytes > 0 && int64(len(r.inStr)) > r.maxBytes {
 			return nil, ErrLimitExceeded
 		}
-		return []byte(r.inStr), nil
 	}
 
-	if r.file != "" {
-		f, err := os.Open(r.file)
+	rc, err := r.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rc.Close() }()
+	return ReadLimited(rc, r.maxBytes)
+}
+
+// Open returns a stream over the input source, transparently decompressing
+// gzip, zstd, bzip2 and xz data and transcoding text to UTF-8. Unlike Read,
+// no size limit is applied, so callers decoding row by row can consume
+// arbitrarily large input.
+func (r *Reader) Open() (io.ReadCloser, error) {
+	rc, err := r.openRaw()
+	if err != nil {
+		return nil, err
+	}
+	dec, err := Decompress(rc)
+	if err != nil {
+		_ = rc.Close()
+		return nil, err
+	}
+	if r.isBinary != nil {
+		br := bufio.NewReaderSize(dec, binarySniffSize)
+		head, _ := br.Peek(binarySniffSize)
+		dec = struct {
+			io.Reader
+			io.Closer
+		}{br, dec}
+		if r.isBinary(head) {
+			return dec, nil
+		}
+	}
+	return r.decode(dec)
+}
+
+// decode transcodes rc to UTF-8, keeping rc as the closer.
+func (r *Reader) decode(rc io.ReadCloser) (io.ReadCloser, error) {
+	text, err := Decode(rc, r.encoding)
+	if err != nil {
+		_ = rc.Close()
+		return nil, err
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{text, rc}, nil
+}
+
+func (r *Reader) openRaw() (io.ReadCloser, error) {
+	if r.inStr != "" {
+		return io.NopCloser(strings.NewReader(r.inStr)), nil
+	}
+
+	if sourcepath.IsURL(r.file) {
+		body, contentType, err := fetch(r.file, r.http)
 		if err != nil {
 			return nil, err
 		}
-		defer func() { _ = f.Close() }()
+		r.contentType = contentType
+		return body, nil
+	}
 
-		if r.maxBytes > 0 {
-			info, err := f.Stat()
-			if err == nil && info.Size() > r.maxBytes {
-				return nil, ErrLimitExceeded
-			}
-		}
-		return r.readLimited(f)
+	if r.file != "" {
+		return os.Open(r.file)
 	}
 
 	if r.stdin == nil {
 		return nil, errors.New("no input available")
 	}
-	return r.readLimited(r.stdin)
+	return io.NopCloser(r.stdin), nil
 }
 
-func (r *Reader) readLimited(reader io.Reader) ([]byte, error) {
-	if r.maxBytes <= 0 {
+// ReadLimited reads reader to the end, failing with ErrLimitExceeded once
+// more than maxBytes have been read. A non-positive maxBytes disables the limit.
+func ReadLimited(reader io.Reader, maxBytes int64) ([]byte, error) {
+	if maxBytes <= 0 {
 		return io.ReadAll(reader)
 	}
 
 	// Read up to maxBytes + 1 to detect if limit is exceeded
-	lr := io.LimitReader(reader, r.maxBytes+1)
+	lr := io.LimitReader(reader, maxBytes+1)
 	data, err := io.ReadAll(lr)
 	if err != nil {
 		return nil, err
 	}
 
-	if int64(len(data)) > r.maxBytes {
+	if int64(len(data)) > maxBytes {
 		return nil, ErrLimitExceeded
 	}
 
 	return data, nil
 }
+
+// ExpandPaths expands shell-style glob patterns (*, ? and [...]) into the
+// matching file paths, in lexical order per pattern. Plain paths and URLs
+// are kept as-is; a pattern without matches is an error.
+func ExpandPaths(patterns []string) ([]string, error) {
+	var paths []string
+	for _, p := range patterns {
+		if sourcepath.IsURL(p) || !strings.ContainsAny(p, "*?[") {
+			paths = append(paths, p)
+			continue
+		}
+		matches, err := filepath.Glob(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid file pattern %q: %w", p, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no files match %q", p)
+		}
+		paths = append(paths, matches...)
+	}
+	return paths, nil
+}
@@ -2,7 +2,9 @@ package input
 
 import (
 	"errors"
+	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -83,3 +85,64 @@ func TestReader_LimitExceeded_Stdin(t *testing.T) {
 		t.Fatalf("expected ErrLimitExceeded, got %v", err)
 	}
 }
+
+func TestReader_Open_IgnoresLimit(t *testing.T) {
+	r := NewReader("", "", strings.NewReader("larger than the limit"), 5)
+	rc, err := r.Open()
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	defer func() { _ = rc.Close() }()
+	b, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if string(b) != "larger than the limit" {
+		t.Fatalf("got %q", string(b))
+	}
+}
+
+func TestReader_Open_MissingFile(t *testing.T) {
+	r := NewReader("", "/does/not/exist.json", nil, 0)
+	if _, err := r.Open(); err == nil {
+		t.Fatalf("expected error")
+	}
+}
+
+func TestReadLimited(t *testing.T) {
+	b, err := ReadLimited(strings.NewReader("12345"), 5)
+	if err != nil || string(b) != "12345" {
+		t.Fatalf("got %q, %v", string(b), err)
+	}
+	if _, err := ReadLimited(strings.NewReader("123456"), 5); !errors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("expected ErrLimitExceeded, got %v", err)
+	}
+	b, err = ReadLimited(strings.NewReader("unbounded"), 0)
+	if err != nil || string(b) != "unbounded" {
+		t.Fatalf("got %q, %v", string(b), err)
+	}
+}
+
+func TestExpandPaths(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"b.jsonl", "a.jsonl", "c.csv"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := ExpandPaths([]string{filepath.Join(dir, "*.jsonl"), "plain.json"})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	want := []string{filepath.Join(dir, "a.jsonl"), filepath.Join(dir, "b.jsonl"), "plain.json"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestExpandPaths_NoMatch(t *testing.T) {
+	_, err := ExpandPaths([]string{filepath.Join(t.TempDir(), "*.json")})
+	if err == nil || !strings.Contains(err.Error(), "no files match") {
+		t.Fatalf("expected no match error, got %v", err)
+	}
+}
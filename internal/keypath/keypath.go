@@ -0,0 +1,230 @@
+// Package keypath reads and writes the paths that name flattened columns,
+// such as metadata.labels."app.kubernetes.io/name" or items[0].sku.
+//
+// Segments are joined by a separator, "." by default. A segment holding the
+// separator, a quote or a bracket is written in double quotes, with \" and
+// \\ escaping a quote and a backslash, so every key has exactly one path.
+// When reading, a segment may also be given in brackets, either quoted or
+// as an index as in items[0] or items[*]; other brackets are ordinary
+// characters, so globs such as [abc]* keep their meaning.
+package keypath
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DefaultSeparator joins the segments of a path.
+const DefaultSeparator = "."
+
+// Segment is one step of a path.
+type Segment struct {
+	Name   string
+	Quoted bool // written in quotes, so never a glob pattern
+}
+
+// Parse splits path into its segments.
+func Parse(path, sep string) ([]Segment, error) {
+	if sep == "" {
+		sep = DefaultSeparator
+	}
+	if !strings.ContainsAny(path, `"[`) {
+		// fast path for the common case
+		parts := strings.Split(path, sep)
+		segs := make([]Segment, len(parts))
+		for i, p := range parts {
+			if p == "" {
+				return nil, fmt.Errorf("empty segment in path %q", path)
+			}
+			segs[i] = Segment{Name: p}
+		}
+		return segs, nil
+	}
+
+	var segs []Segment
+	i := 0
+	for {
+		var seg Segment
+		var err error
+		switch {
+		case strings.HasPrefix(path[i:], `"`):
+			seg.Quoted = true
+			seg.Name, i, err = readQuoted(path, i)
+		case isBracket(path[i:]):
+			seg, i, err = readBracket(path, i)
+		default:
+			end := i
+			for end < len(path) && !isBracket(path[end:]) && !strings.HasPrefix(path[end:], sep) {
+				end++
+			}
+			seg.Name, i = path[i:end], end
+			if seg.Name == "" {
+				err = fmt.Errorf("empty segment in path %q", path)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		segs = append(segs, seg)
+
+		switch {
+		case i == len(path):
+			return segs, nil
+		case isBracket(path[i:]):
+			// items[0] needs no separator
+		case strings.HasPrefix(path[i:], sep):
+			i += len(sep)
+			if i == len(path) {
+				return nil, fmt.Errorf("empty segment in path %q", path)
+			}
+		default:
+			return nil, fmt.Errorf("unexpected %q at offset %d of path %q", path[i:i+1], i, path)
+		}
+	}
+}
+
+// readQuoted reads the quoted string starting at path[i], returning its
+// unescaped text and the offset after the closing quote.
+func readQuoted(path string, i int) (string, int, error) {
+	var b strings.Builder
+	for j := i + 1; j < len(path); j++ {
+		switch path[j] {
+		case '\\':
+			if j+1 < len(path) {
+				j++
+				b.WriteByte(path[j])
+			}
+		case '"':
+			return b.String(), j + 1, nil
+		default:
+			b.WriteByte(path[j])
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated quote in path %q", path)
+}
+
+// isBracket reports whether s starts with a bracketed segment: ["name"],
+// [0] or [*].
+func isBracket(s string) bool {
+	if strings.HasPrefix(s, `["`) {
+		return true
+	}
+	end := strings.IndexByte(s, ']')
+	if !strings.HasPrefix(s, "[") || end < 2 {
+		return false
+	}
+	inner := s[1:end]
+	if inner == "*" {
+		return true
+	}
+	return strings.Trim(inner, "0123456789") == ""
+}
+
+// readBracket reads the bracketed segment starting at path[i].
+func readBracket(path string, i int) (Segment, int, error) {
+	i++
+	if strings.HasPrefix(path[i:], `"`) {
+		name, end, err := readQuoted(path, i)
+		if err != nil {
+			return Segment{}, 0, err
+		}
+		if !strings.HasPrefix(path[end:], "]") {
+			return Segment{}, 0, fmt.Errorf("missing ] in path %q", path)
+		}
+		return Segment{Name: name, Quoted: true}, end + 1, nil
+	}
+	end := strings.IndexByte(path[i:], ']')
+	return Segment{Name: path[i : i+end]}, i + end + 1, nil
+}
+
+// Split returns the names of the segments of path.
+func Split(path, sep string) ([]string, error) {
+	segs, err := Parse(path, sep)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(segs))
+	for i, s := range segs {
+		names[i] = s.Name
+	}
+	return names, nil
+}
+
+// Quote returns name as a path segment, in quotes when it is empty or holds
+// the separator, a quote, a bracket or a backslash.
+func Quote(name, sep string) string {
+	if sep == "" {
+		sep = DefaultSeparator
+	}
+	if name != "" && !strings.ContainsAny(name, `"[]\`) && !strings.Contains(name, sep) {
+		return name
+	}
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(name); i++ {
+		if name[i] == '"' || name[i] == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(name[i])
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// Join writes names as a path, quoting the segments that need it.
+func Join(names []string, sep string) string {
+	if sep == "" {
+		sep = DefaultSeparator
+	}
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = Quote(n, sep)
+	}
+	return strings.Join(quoted, sep)
+}
+
+// Canonical rewrites path as the flattened key it names, so that
+// labels["app"] and labels."app" both become labels.app.
+func Canonical(path, sep string) (string, error) {
+	names, err := Split(path, sep)
+	if err != nil {
+		return "", err
+	}
+	return Join(names, sep), nil
+}
+
+// SplitList splits a comma-separated list of paths, leaving commas inside
+// quoted segments alone. Items are trimmed and empty ones dropped.
+func SplitList(s string) []string {
+	var items []string
+	quoted := false
+	start := 0
+	for i := 0; i <= len(s); i++ {
+		switch {
+		case i < len(s) && s[i] == '\\' && quoted:
+			i++
+		case i < len(s) && s[i] == '"':
+			quoted = !quoted
+		case i == len(s) || (s[i] == ',' && !quoted):
+			if item := strings.TrimSpace(s[start:i]); item != "" {
+				items = append(items, item)
+			}
+			start = i + 1
+		}
+	}
+	return items
+}
+
+// ValidateSeparator checks that sep can separate path segments: it must not
+// be empty, and must not use the characters that quote segments, separate
+// lists of paths, form globs or filter operators, or whitespace.
+func ValidateSeparator(sep string) error {
+	if sep == "" {
+		return errors.New("separator must not be empty")
+	}
+	if strings.ContainsAny(sep, "\"[]\\*?,=<>!~ \t\r\n") {
+		return fmt.Errorf("separator %q must not contain quotes, brackets, backslashes, globs, commas, filter operators or whitespace", sep)
+	}
+	return nil
+}
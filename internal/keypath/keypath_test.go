@@ -0,0 +1,96 @@
+package keypath
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		path string
+		sep  string
+		want []Segment
+	}{
+		{"a.b", "", []Segment{{Name: "a"}, {Name: "b"}}},
+		{`labels."app.kubernetes.io/name"`, ".", []Segment{{Name: "labels"}, {Name: "app.kubernetes.io/name", Quoted: true}}},
+		{`labels["app.kubernetes.io/name"].x`, ".", []Segment{{Name: "labels"}, {Name: "app.kubernetes.io/name", Quoted: true}, {Name: "x"}}},
+		{"items[0].sku", ".", []Segment{{Name: "items"}, {Name: "0"}, {Name: "sku"}}},
+		{"items[*][1]", ".", []Segment{{Name: "items"}, {Name: "*"}, {Name: "1"}}},
+		{`"say \"hi\"".b`, ".", []Segment{{Name: `say "hi"`, Quoted: true}, {Name: "b"}}},
+		{`"".x`, ".", []Segment{{Name: "", Quoted: true}, {Name: "x"}}},
+		{"versions/1.0/url", "/", []Segment{{Name: "versions"}, {Name: "1.0"}, {Name: "url"}}},
+		{`a__"b__c"__d`, "__", []Segment{{Name: "a"}, {Name: "b__c", Quoted: true}, {Name: "d"}}},
+		{"[abc]*.x[y]", ".", []Segment{{Name: "[abc]*"}, {Name: "x[y]"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := Parse(tt.path, tt.sep)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, path := range []string{"", "a..b", "a.", `a."b`, `a."b"c`, `a["b"`, `a["b"c]`} {
+		if _, err := Parse(path, "."); err == nil {
+			t.Fatalf("expected error for %q", path)
+		}
+	}
+}
+
+func TestJoinRoundTrip(t *testing.T) {
+	tests := []struct {
+		names []string
+		sep   string
+		want  string
+	}{
+		{[]string{"metadata", "name"}, ".", "metadata.name"},
+		{[]string{"labels", "app.kubernetes.io/name"}, ".", `labels."app.kubernetes.io/name"`},
+		{[]string{"labels", "app.kubernetes.io/name"}, "/", `labels/"app.kubernetes.io/name"`},
+		{[]string{"versions", "1.0"}, "/", "versions/1.0"},
+		{[]string{`a"b`, `c\d`, "e[0]", ""}, ".", `"a\"b"."c\\d"."e[0]".""`},
+	}
+	for _, tt := range tests {
+		got := Join(tt.names, tt.sep)
+		if got != tt.want {
+			t.Fatalf("Join(%q) = %s, want %s", tt.names, got, tt.want)
+		}
+		back, err := Split(got, tt.sep)
+		if err != nil || !reflect.DeepEqual(back, tt.names) {
+			t.Fatalf("Split(%s) = %q, %v; want %q", got, back, err, tt.names)
+		}
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	got, err := Canonical(`labels["app"]["a.b"]`, ".")
+	if err != nil || got != `labels.app."a.b"` {
+		t.Fatalf("got %s, %v", got, err)
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	got := SplitList(` name, labels."a,b",, x."say \"hi,\""=1 ,-age`)
+	want := []string{"name", `labels."a,b"`, `x."say \"hi,\""=1`, "-age"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestValidateSeparator(t *testing.T) {
+	for _, sep := range []string{".", "/", "__", "::", "|"} {
+		if err := ValidateSeparator(sep); err != nil {
+			t.Fatalf("unexpected error for %q: %v", sep, err)
+		}
+	}
+	for _, sep := range []string{"", `"`, "[", "*", ",", "=", " "} {
+		if err := ValidateSeparator(sep); err == nil {
+			t.Fatalf("expected error for %q", sep)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package parse
+
+import (
+	"bytes"
+	"errors"
+	"io"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// cborSelfDescribe is the optional tag 55799 that marks CBOR data.
+var cborSelfDescribe = []byte{0xd9, 0xd9, 0xf7}
+
+// parseCBOR decodes a CBOR value, or a sequence of concatenated values,
+// which are returned as an array. Tags other than timestamps are dropped
+// in favor of their content.
+func parseCBOR(data []byte) (any, error) {
+	dec := cbor.NewDecoder(bytes.NewReader(data))
+	var docs []any
+	for {
+		var v any
+		if err := dec.Decode(&v); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		docs = append(docs, binaryValue(untagCBOR(v)))
+	}
+	if len(docs) == 1 {
+		return docs[0], nil
+	}
+	return docs, nil
+}
+
+func untagCBOR(v any) any {
+	switch t := v.(type) {
+	case cbor.Tag:
+		return untagCBOR(t.Content)
+	case map[any]any:
+		for k, vv := range t {
+			t[k] = untagCBOR(vv)
+		}
+		return t
+	case []any:
+		for i := range t {
+			t[i] = untagCBOR(t[i])
+		}
+		return t
+	default:
+		return v
+	}
+}
@@ -0,0 +1,60 @@
+package parse
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+func TestParseCBOR(t *testing.T) {
+	enc, err := cbor.EncOptions{Time: cbor.TimeRFC3339, TimeTag: cbor.EncTagRequired}.EncMode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := enc.Marshal(map[any]any{
+		"id":   1,
+		7:      "seven",
+		"at":   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		"blob": []byte("text"),
+		"tag":  cbor.Tag{Number: 32, Content: "https://example.com"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Parse(data, CBOR, ParseOptions{})
+	if err != nil {
+		t.Fatalf("parse cbor: %v", err)
+	}
+	want := map[string]any{
+		"id":   uint64(1),
+		"7":    "seven",
+		"at":   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		"blob": "text",
+		"tag":  "https://example.com",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("mismatch:\n got  %#v\n want %#v", got, want)
+	}
+}
+
+func TestParseCBORSequence(t *testing.T) {
+	a, _ := cbor.Marshal(map[string]int{"n": 1})
+	b, _ := cbor.Marshal(map[string]int{"n": 2})
+	got, err := Parse(append(a, b...), CBOR, ParseOptions{})
+	if err != nil {
+		t.Fatalf("parse cbor: %v", err)
+	}
+	want := []any{map[string]any{"n": uint64(1)}, map[string]any{"n": uint64(2)}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("mismatch:\n got  %#v\n want %#v", got, want)
+	}
+}
+
+func TestParseCBORTruncated(t *testing.T) {
+	data, _ := cbor.Marshal(map[string]string{"key": "value"})
+	if _, err := Parse(data[:len(data)-2], CBOR, ParseOptions{}); err == nil {
+		t.Fatal("expected error for truncated cbor data")
+	}
+}
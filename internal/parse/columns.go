@@ -0,0 +1,148 @@
+package parse
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"strings"
+)
+
+// tabWidth is the distance between tab stops when aligning columns.
+const tabWidth = 8
+
+// parseColumns reads a whitespace-aligned text table, as printed by ps,
+// df, docker or kubectl, into rows keyed by the header row.
+//
+// Column boundaries are the character positions that are blank on every
+// line. Text found between boundaries but not under any header, such as
+// right-aligned numbers wider than their header or spaces inside the last
+// column, belongs to the column on its left. Header words a single space
+// apart over an empty column, such as "Mounted on", stay one header.
+func parseColumns(data []byte) (any, error) {
+	var lines [][]rune
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := strings.TrimRight(expandTabs(sc.Text()), " \r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		lines = append(lines, []rune(line))
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, errors.New("no header row")
+	}
+	header, records := lines[0], lines[1:]
+
+	spans := columnSpans(header, records)
+	result := make([]map[string]any, 0, len(records))
+	for _, rec := range records {
+		row := make(map[string]any, len(spans))
+		for i, sp := range spans {
+			end := len(rec)
+			if i+1 < len(spans) {
+				end = spans[i+1].start
+			}
+			row[sp.name] = strings.TrimSpace(runeSlice(rec, sp.start, end))
+		}
+		result = append(result, row)
+	}
+	return result, nil
+}
+
+// columnSpan is a column named by the header text above it, starting at
+// rune offset start and ending where the next column starts.
+type columnSpan struct {
+	name       string
+	start, end int
+}
+
+func columnSpans(header []rune, records [][]rune) []columnSpan {
+	width := len(header)
+	for _, rec := range records {
+		width = max(width, len(rec))
+	}
+	occupied := make([]bool, width)
+	for _, line := range append([][]rune{header}, records...) {
+		for i, r := range line {
+			if r != ' ' {
+				occupied[i] = true
+			}
+		}
+	}
+
+	var spans []columnSpan
+	for i := 0; i < width; {
+		if !occupied[i] {
+			i++
+			continue
+		}
+		start := i
+		for i < width && occupied[i] {
+			i++
+		}
+		sp := columnSpan{start: start, end: i}
+		name := strings.TrimSpace(runeSlice(header, sp.start, sp.end))
+		switch {
+		case len(spans) > 0 && name == "":
+			// data without a header of its own
+			spans[len(spans)-1].end = sp.end
+			continue
+		case len(spans) > 0 && start-spans[len(spans)-1].end == 1 && columnEmpty(records, sp):
+			// the next word of a header such as "Mounted on"
+			spans[len(spans)-1].end = sp.end
+			continue
+		}
+		spans = append(spans, sp)
+	}
+	if len(spans) > 0 && strings.TrimSpace(runeSlice(header, spans[0].start, spans[0].end)) == "" && len(spans) > 1 {
+		// leading data without a header belongs to the first named column
+		spans[1].start = spans[0].start
+		spans = spans[1:]
+	}
+	for i := range spans {
+		spans[i].name = strings.TrimSpace(runeSlice(header, spans[i].start, spans[i].end))
+	}
+	return spans
+}
+
+// columnEmpty reports whether no record has text within sp.
+func columnEmpty(records [][]rune, sp columnSpan) bool {
+	for _, rec := range records {
+		if strings.TrimSpace(runeSlice(rec, sp.start, sp.end)) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// runeSlice returns line[start:end], clamped to the length of line.
+func runeSlice(line []rune, start, end int) string {
+	if start >= len(line) {
+		return ""
+	}
+	return string(line[start:min(end, len(line))])
+}
+
+// expandTabs replaces tabs with the spaces up to the next tab stop.
+func expandTabs(s string) string {
+	if !strings.Contains(s, "\t") {
+		return s
+	}
+	var b strings.Builder
+	col := 0
+	for _, r := range s {
+		if r == '\t' {
+			n := tabWidth - col%tabWidth
+			b.WriteString(strings.Repeat(" ", n))
+			col += n
+			continue
+		}
+		b.WriteRune(r)
+		col++
+	}
+	return b.String()
+}
@@ -0,0 +1,74 @@
+package parse
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseColumns(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []map[string]any
+	}{
+		{
+			"docker ps",
+			`CONTAINER ID   IMAGE          COMMAND                  CREATED       STATUS                   PORTS     NAMES
+a1b2c3d4e5f6   nginx:1.25     "/docker-entrypoint.…"   2 hours ago   Up 2 hours               80/tcp    web
+0f9e8d7c6b5a   redis:7        "docker-entrypoint.s…"   3 days ago    Exited (0) 2 hours ago             cache
+`,
+			[]map[string]any{
+				{"CONTAINER ID": "a1b2c3d4e5f6", "IMAGE": "nginx:1.25", "COMMAND": `"/docker-entrypoint.…"`, "CREATED": "2 hours ago", "STATUS": "Up 2 hours", "PORTS": "80/tcp", "NAMES": "web"},
+				{"CONTAINER ID": "0f9e8d7c6b5a", "IMAGE": "redis:7", "COMMAND": `"docker-entrypoint.s…"`, "CREATED": "3 days ago", "STATUS": "Exited (0) 2 hours ago", "PORTS": "", "NAMES": "cache"},
+			},
+		},
+		{
+			"ps aux with right-aligned numbers",
+			`USER         PID %CPU %MEM    VSZ   RSS TTY      STAT START   TIME COMMAND
+root           1  0.0  0.1 167772 11520 ?        Ss   Oct16   0:05 /sbin/init splash
+www-data   12345 12.5  3.2   9000   800 pts/0    R+   10:01  12:30 nginx: worker process
+`,
+			[]map[string]any{
+				{"USER": "root", "PID": "1", "%CPU": "0.0", "%MEM": "0.1", "VSZ": "167772", "RSS": "11520", "TTY": "?", "STAT": "Ss", "START": "Oct16", "TIME": "0:05", "COMMAND": "/sbin/init splash"},
+				{"USER": "www-data", "PID": "12345", "%CPU": "12.5", "%MEM": "3.2", "VSZ": "9000", "RSS": "800", "TTY": "pts/0", "STAT": "R+", "START": "10:01", "TIME": "12:30", "COMMAND": "nginx: worker process"},
+			},
+		},
+		{
+			"df with a two-word last header",
+			`Filesystem      Size  Used Avail Use% Mounted on
+/dev/sda1        50G   20G   28G  42% /
+tmpfs           7.8G     0  7.8G   0% /dev/shm
+`,
+			[]map[string]any{
+				{"Filesystem": "/dev/sda1", "Size": "50G", "Used": "20G", "Avail": "28G", "Use%": "42%", "Mounted on": "/"},
+				{"Filesystem": "tmpfs", "Size": "7.8G", "Used": "0", "Avail": "7.8G", "Use%": "0%", "Mounted on": "/dev/shm"},
+			},
+		},
+		{
+			"kubectl with tabs and blank lines",
+			"NAME\tREADY\tSTATUS\n\napi-7d9\t1/1\tRunning\n",
+			[]map[string]any{{"NAME": "api-7d9", "READY": "1/1", "STATUS": "Running"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse([]byte(tt.data), Columns, ParseOptions{})
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got  %#v\nwant %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseColumnsEmpty(t *testing.T) {
+	if _, err := Parse([]byte("\n  \n"), Columns, ParseOptions{}); err == nil {
+		t.Fatal("expected error for input without a header")
+	}
+	got, err := Parse([]byte("NAME  AGE\n"), Columns, ParseOptions{})
+	if err != nil || len(got.([]map[string]any)) != 0 {
+		t.Fatalf("expected no rows, got %v %v", got, err)
+	}
+}
@@ -0,0 +1,197 @@
+package parse
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// csvDelimiters are the delimiters recognized when sniffing, in order of
+// preference on ties.
+var csvDelimiters = []rune{',', '\t', ';', '|'}
+
+// sniffDelimiter picks the CSV delimiter occurring most often in a header
+// line, ignoring quoted text. It defaults to a comma.
+func sniffDelimiter(line string) rune {
+	best, bestCount := ',', 0
+	for _, d := range csvDelimiters {
+		if n := countDelimiter(line, d); n > bestCount {
+			best, bestCount = d, n
+		}
+	}
+	return best
+}
+
+// csvSkipsLine reports whether the CSV reader ignores line: an empty line
+// or, when comment is set, a comment line.
+func csvSkipsLine(line string, comment rune) bool {
+	line = strings.TrimRight(line, "\r\n")
+	return line == "" || comment != 0 && strings.HasPrefix(line, string(comment))
+}
+
+// countDelimiter counts the occurrences of d in line outside double quotes.
+func countDelimiter(line string, d rune) int {
+	n, quoted := 0, false
+	for _, r := range line {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == d && !quoted:
+			n++
+		}
+	}
+	return n
+}
+
+// sniffDelimited reports the delimiter of data that looks like delimited
+// text separated by something other than commas: the first two lines must
+// hold the same, non-zero number of semicolons, tabs or pipes.
+func sniffDelimited(data []byte) (rune, bool) {
+	lines := strings.SplitN(string(data), "\n", 3)
+	if len(lines) < 2 {
+		return 0, false
+	}
+	first := strings.TrimSuffix(lines[0], "\r")
+	second := strings.TrimSuffix(lines[1], "\r")
+	if strings.ContainsAny(first, "{:") {
+		return 0, false
+	}
+	d := sniffDelimiter(first)
+	if d == ',' {
+		return 0, false
+	}
+	n := countDelimiter(first, d)
+	return d, n > 0 && countDelimiter(second, d) == n
+}
+
+// parseCSV converts CSV data to []map[string]any
+func parseCSV(data []byte, opts ParseOptions) (any, error) {
+	it := &csvIterator{src: bytes.NewReader(data), opts: opts}
+	var result []map[string]any
+	for {
+		row, err := it.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, row.(map[string]any))
+	}
+	if it.headers == nil {
+		return []map[string]any{}, nil
+	}
+	return result, nil
+}
+
+// csvIterator yields one map[string]any per CSV record, keyed by the
+// header row or by generated col0, col1, ... names. The reader is set up
+// on the first call to Next: preamble lines are skipped and, unless a
+// delimiter is configured, it is sniffed from the header line, the first
+// one that is neither blank nor a comment.
+type csvIterator struct {
+	src     io.Reader
+	opts    ParseOptions
+	r       *csv.Reader
+	delim   rune
+	skipped int // preamble lines, added to reported line numbers
+	headers []string
+}
+
+func (it *csvIterator) init() {
+	br := bufio.NewReader(it.src)
+	for ; it.skipped < it.opts.CSVSkipRows; it.skipped++ {
+		if _, err := br.ReadString('\n'); err != nil {
+			break
+		}
+	}
+
+	var src io.Reader = br
+	it.delim = it.opts.CSVDelimiter
+	if it.delim == 0 {
+		// sniff from the header, past blank and comment lines
+		var head strings.Builder
+		for {
+			line, err := br.ReadString('\n')
+			head.WriteString(line)
+			if err != nil || !csvSkipsLine(line, it.opts.CSVComment) {
+				it.delim = sniffDelimiter(line)
+				break
+			}
+		}
+		src = io.MultiReader(strings.NewReader(head.String()), br)
+	}
+
+	it.r = csv.NewReader(src)
+	it.r.Comma = it.delim
+	it.r.Comment = it.opts.CSVComment
+	it.r.LazyQuotes = it.opts.CSVLazyQuotes
+	it.r.TrimLeadingSpace = it.opts.CSVTrimSpace
+}
+
+func (it *csvIterator) Next() (any, error) {
+	if it.r == nil {
+		it.init()
+	}
+	record, err := it.r.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, &RowError{
+				Line:    parseErr.Line + it.skipped,
+				Column:  parseErr.Column,
+				Snippet: snippet(strings.Join(record, string(it.delim))),
+				Err:     parseErr.Err,
+			}
+		}
+		return nil, err
+	}
+	if it.opts.CSVTrimSpace {
+		for i := range record {
+			record[i] = strings.TrimSpace(record[i])
+		}
+	}
+	if it.headers == nil {
+		if it.opts.CSVNoHeader {
+			it.headers = make([]string, len(record))
+			for i := range it.headers {
+				it.headers[i] = fmt.Sprintf("col%d", i)
+			}
+		} else {
+			it.headers = record
+			return it.Next()
+		}
+	}
+	obj := make(map[string]any)
+	for j, value := range record {
+		if j >= len(it.headers) {
+			continue
+		}
+		v, err := it.convert(it.headers[j], value)
+		if err != nil {
+			line, col := it.r.FieldPos(j)
+			return nil, &RowError{Line: line + it.skipped, Column: col, Snippet: snippet(value), Err: err}
+		}
+		obj[it.headers[j]] = v
+	}
+	return obj, nil
+}
+
+// convert applies the configured column type, or type inference, to a cell.
+func (it *csvIterator) convert(header, value string) (any, error) {
+	if t, ok := it.opts.CSVTypes[header]; ok {
+		v, err := convertCell(value, t)
+		if err != nil {
+			return nil, fmt.Errorf("column %q: %w", header, err)
+		}
+		return v, nil
+	}
+	if it.opts.CSVInferTypes {
+		return inferCell(value), nil
+	}
+	return value, nil
+}
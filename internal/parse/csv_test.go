@@ -0,0 +1,133 @@
+package parse
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSniffDelimiter(t *testing.T) {
+	tests := map[string]rune{
+		"name,age,city":         ',',
+		"name;age;city":         ';',
+		"name\tage\tcity":       '\t',
+		"name|age|city":         '|',
+		`"last, first";age;zip`: ';',
+		"single":                ',',
+		"a,b;c":                 ',',
+	}
+	for line, want := range tests {
+		if got := sniffDelimiter(line); got != want {
+			t.Errorf("sniffDelimiter(%q) = %q, want %q", line, got, want)
+		}
+	}
+}
+
+func TestDetect_DelimitedSniff(t *testing.T) {
+	tests := []struct {
+		name string
+		d    Detector
+		data string
+		want Format
+	}{
+		{"semicolons", Detector{}, "name;price\nKäse;1,50\n", CSV},
+		{"pipes", Detector{}, "a|b|c\n1|2|3\n", CSV},
+		{"tabs", Detector{}, "a\tb\n1\t2\n", TSV},
+		{"tsv extension", Detector{FilePath: "data.TSV"}, "a b", TSV},
+		{"tsv explicit", Detector{Explicit: "tsv"}, "{}", TSV},
+		{"tsv content type", Detector{ContentType: "text/tab-separated-values"}, "", TSV},
+		{"inconsistent", Detector{}, "hello; world\nplain text\n", YAML},
+		{"yaml", Detector{}, "key: a;b\nother: c;d\n", YAML},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.Detect([]byte(tt.data)); got != tt.want {
+				t.Fatalf("want %v got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseCSV_Dialect(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		f    Format
+		opts ParseOptions
+		want []map[string]any
+	}{
+		{
+			name: "sniffed semicolons",
+			data: "name;price\nKäse;\"1,50\"\n",
+			f:    CSV,
+			want: []map[string]any{{"name": "Käse", "price": "1,50"}},
+		},
+		{
+			name: "explicit delimiter",
+			data: "a|b\n1|2\n",
+			f:    CSV,
+			opts: ParseOptions{CSVDelimiter: '|'},
+			want: []map[string]any{{"a": "1", "b": "2"}},
+		},
+		{
+			name: "tsv",
+			data: "a\tb,c\n1\t2,3\n",
+			f:    TSV,
+			want: []map[string]any{{"a": "1", "b,c": "2,3"}},
+		},
+		{
+			name: "comments",
+			data: "# exported\na,b\n# note\n1,2\n",
+			f:    CSV,
+			opts: ParseOptions{CSVComment: '#'},
+			want: []map[string]any{{"a": "1", "b": "2"}},
+		},
+		{
+			name: "sniffed past comments",
+			data: "# exported by tool, v2, with commas\n\n# more, notes\na;b\n1;2,5\n",
+			f:    CSV,
+			opts: ParseOptions{CSVComment: '#'},
+			want: []map[string]any{{"a": "1", "b": "2,5"}},
+		},
+		{
+			name: "lazy quotes",
+			data: "a,b\n1,say \"hi\"\n",
+			f:    CSV,
+			opts: ParseOptions{CSVLazyQuotes: true},
+			want: []map[string]any{{"a": "1", "b": `say "hi"`}},
+		},
+		{
+			name: "trim space",
+			data: "a , b\n 1 ,  2 \n",
+			f:    CSV,
+			opts: ParseOptions{CSVTrimSpace: true},
+			want: []map[string]any{{"a": "1", "b": "2"}},
+		},
+		{
+			name: "skip rows",
+			data: "Report generated 2024-01-01\n\na;b\n1;2\n",
+			f:    CSV,
+			opts: ParseOptions{CSVSkipRows: 2},
+			want: []map[string]any{{"a": "1", "b": "2"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse([]byte(tt.data), tt.f, tt.opts)
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCSV_SkipRowsLineNumbers(t *testing.T) {
+	_, err := Parse([]byte("preamble\na,b\n1\n"), CSV, ParseOptions{CSVSkipRows: 1})
+	var rowErr *RowError
+	if !errors.As(err, &rowErr) || rowErr.Line != 3 {
+		t.Fatalf("expected RowError on line 3, got %v", err)
+	}
+}
@@ -0,0 +1,160 @@
+package parse
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// looksLikeHTML reports whether markup data is an HTML document or
+// fragment rather than XML.
+func looksLikeHTML(data []byte) bool {
+	head := bytes.ToLower(data[:min(len(data), 512)])
+	for _, tag := range []string{"<!doctype html", "<html", "<table", "<body", "<head"} {
+		if bytes.Contains(head, []byte(tag)) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseHTML reads the index-th <table> of an HTML document (1-based, 0
+// meaning the first) into rows keyed by its header cells. The header is the
+// <thead> row, or the first row when it holds only <th> cells; otherwise
+// columns are named col0, col1, ... Cells spanning several columns repeat
+// their text in each.
+func parseHTML(data []byte, index int) (any, error) {
+	doc, err := html.Parse(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	var tables []*html.Node
+	var find func(*html.Node)
+	find = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.DataAtom == atom.Table {
+			tables = append(tables, n)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			find(c)
+		}
+	}
+	find(doc)
+	table, err := pickTable(len(tables), index)
+	if err != nil {
+		return nil, err
+	}
+
+	var headers []string
+	result := []map[string]any{}
+	for i, tr := range htmlRows(tables[table]) {
+		cells, allTH, inHead := htmlCells(tr)
+		if i == 0 && (inHead || allTH) {
+			headers = cells
+			continue
+		}
+		if len(cells) == 0 {
+			continue
+		}
+		result = append(result, tableRow(headers, cells))
+	}
+	return result, nil
+}
+
+// pickTable resolves a 1-based table index, 0 meaning the first table.
+func pickTable(count, index int) (int, error) {
+	if count == 0 {
+		return 0, fmt.Errorf("no table found")
+	}
+	if index == 0 {
+		index = 1
+	}
+	if index < 1 || index > count {
+		return 0, fmt.Errorf("table %d not found; input has %d tables", index, count)
+	}
+	return index - 1, nil
+}
+
+// tableRow keys cells by headers, naming cells beyond them col0, col1, ...
+func tableRow(headers, cells []string) map[string]any {
+	row := make(map[string]any, len(cells))
+	for j, v := range cells {
+		key := fmt.Sprintf("col%d", j)
+		if j < len(headers) && headers[j] != "" {
+			key = headers[j]
+		}
+		row[key] = v
+	}
+	return row
+}
+
+// htmlRows returns the <tr> elements of table, leaving out those of tables
+// nested in its cells.
+func htmlRows(table *html.Node) []*html.Node {
+	var rows []*html.Node
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != html.ElementNode {
+				continue
+			}
+			switch c.DataAtom {
+			case atom.Tr:
+				rows = append(rows, c)
+			case atom.Thead, atom.Tbody, atom.Tfoot:
+				walk(c)
+			}
+		}
+	}
+	walk(table)
+	return rows
+}
+
+// htmlCells returns the text of the cells of tr, whether they are all <th>
+// cells, and whether tr is in a <thead>.
+func htmlCells(tr *html.Node) (cells []string, allTH, inHead bool) {
+	allTH = true
+	for c := tr.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode || (c.DataAtom != atom.Td && c.DataAtom != atom.Th) {
+			continue
+		}
+		if c.DataAtom == atom.Td {
+			allTH = false
+		}
+		text := strings.Join(strings.Fields(htmlText(c)), " ")
+		span := 1
+		for _, a := range c.Attr {
+			if a.Key == "colspan" {
+				if n, err := strconv.Atoi(a.Val); err == nil && n > 1 && n <= 1000 {
+					span = n
+				}
+			}
+		}
+		for range span {
+			cells = append(cells, text)
+		}
+	}
+	inHead = tr.Parent != nil && tr.Parent.DataAtom == atom.Thead
+	return cells, allTH && len(cells) > 0, inHead
+}
+
+func htmlText(n *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			b.WriteString(n.Data)
+		case n.Type == html.ElementNode && n.DataAtom == atom.Br:
+			b.WriteString(" ")
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return b.String()
+}
@@ -0,0 +1,57 @@
+package parse
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const htmlDoc = `<!DOCTYPE html>
+<html><body>
+<table class="go-pretty-table">
+  <thead>
+  <tr><th align="right">a</th><th>b</th></tr>
+  </thead>
+  <tbody>
+  <tr><td align="right">1</td><td>x &amp; <b>y</b></td></tr>
+  <tr><td colspan="2">both</td></tr>
+  </tbody>
+</table>
+<table>
+  <tr><td>k</td><td><table><tr><td>nested</td></tr></table></td></tr>
+  <tr><td>v</td><td>w</td><td>extra</td></tr>
+</table>
+</body></html>`
+
+func TestParseHTML(t *testing.T) {
+	got, err := Parse([]byte(htmlDoc), HTML, ParseOptions{})
+	if err != nil {
+		t.Fatalf("parse html: %v", err)
+	}
+	want := []map[string]any{
+		{"a": "1", "b": "x & y"},
+		{"a": "both", "b": "both"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got  %#v\nwant %#v", got, want)
+	}
+
+	// without <th> cells the columns are numbered; rows of nested tables
+	// stay with their own table
+	got, err = Parse([]byte(htmlDoc), HTML, ParseOptions{TableIndex: 2})
+	if err != nil {
+		t.Fatalf("parse html: %v", err)
+	}
+	want = []map[string]any{
+		{"col0": "k", "col1": "nested"},
+		{"col0": "v", "col1": "w", "col2": "extra"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("table 2: got  %#v\nwant %#v", got, want)
+	}
+
+	_, err = Parse([]byte(htmlDoc), HTML, ParseOptions{TableIndex: 4})
+	if err == nil || !strings.Contains(err.Error(), "table 4 not found; input has 3 tables") {
+		t.Fatalf("expected missing table error, got %v", err)
+	}
+}
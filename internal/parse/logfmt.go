@@ -0,0 +1,148 @@
+package parse
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// logfmtIterator decodes one logfmt record per line, such as
+//
+//	ts=2024-05-01T10:00:00Z level=error msg="disk full" retry
+//
+// Values are kept as strings; a key without a value is true.
+type logfmtIterator struct {
+	r    *bufio.Reader
+	done bool
+	line int
+}
+
+func (it *logfmtIterator) Next() (any, error) {
+	for !it.done {
+		raw, err := it.r.ReadBytes('\n')
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return nil, err
+			}
+			it.done = true
+		}
+		it.line++
+		line := strings.TrimRight(string(raw), "\r\n")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		row, col, err := parseLogfmtLine(line)
+		if err != nil {
+			return nil, &RowError{Line: it.line, Column: col, Snippet: snippet(strings.TrimSpace(line)), Err: err}
+		}
+		return row, nil
+	}
+	return nil, io.EOF
+}
+
+// parseLogfmtLine splits a line into its key=value pairs. On error it
+// returns the 1-based column at which the line stopped making sense.
+func parseLogfmtLine(line string) (map[string]any, int, error) {
+	row := make(map[string]any)
+	i := 0
+	for {
+		for i < len(line) && isLogfmtSpace(line[i]) {
+			i++
+		}
+		if i == len(line) {
+			return row, 0, nil
+		}
+		start := i
+		for i < len(line) && !isLogfmtSpace(line[i]) && line[i] != '=' && line[i] != '"' {
+			i++
+		}
+		key := line[start:i]
+		if key == "" {
+			return nil, i + 1, errors.New("expected a key")
+		}
+		if i == len(line) || isLogfmtSpace(line[i]) {
+			row[key] = true
+			continue
+		}
+		if line[i] == '"' {
+			return nil, i + 1, errors.New("unexpected quote in key")
+		}
+		i++ // '='
+		if i < len(line) && line[i] == '"' {
+			end := closingQuote(line, i)
+			if end < 0 {
+				return nil, i + 1, errors.New("unterminated quoted value")
+			}
+			value, err := strconv.Unquote(line[i : end+1])
+			if err != nil {
+				return nil, i + 1, err
+			}
+			row[key] = value
+			i = end + 1
+			if i < len(line) && !isLogfmtSpace(line[i]) {
+				return nil, i + 1, errors.New("expected a space after quoted value")
+			}
+			continue
+		}
+		start = i
+		for i < len(line) && !isLogfmtSpace(line[i]) {
+			if line[i] == '"' {
+				return nil, i + 1, errors.New("unexpected quote in value")
+			}
+			i++
+		}
+		row[key] = line[start:i]
+	}
+}
+
+// closingQuote returns the index of the quote ending the string that opens
+// at line[open], or -1.
+func closingQuote(line string, open int) int {
+	for i := open + 1; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+func isLogfmtSpace(c byte) bool {
+	return c == ' ' || c == '\t'
+}
+
+// looksLikeLogfmt reports whether the first lines of data are logfmt
+// records made only of key=value pairs. Lines need at least two pairs, so
+// that "a=1,b=2" is still taken for CSV.
+func looksLikeLogfmt(data []byte) bool {
+	lines := bytes.Split(data, []byte("\n"))
+	if len(lines) > 1 && !bytes.HasSuffix(data, []byte("\n")) {
+		// the sample may end in the middle of a line
+		lines = lines[:len(lines)-1]
+	}
+	checked := 0
+	for _, raw := range lines {
+		line := strings.TrimSpace(string(raw))
+		if line == "" {
+			continue
+		}
+		row, _, err := parseLogfmtLine(line)
+		if err != nil || len(row) < 2 {
+			return false
+		}
+		for _, v := range row {
+			if v == true {
+				return false
+			}
+		}
+		if checked++; checked == 5 {
+			break
+		}
+	}
+	return checked > 0
+}
@@ -0,0 +1,86 @@
+package parse
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseLogfmtLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want map[string]any
+		col  int
+	}{
+		{"plain", `level=info msg=started port=8080`, map[string]any{"level": "info", "msg": "started", "port": "8080"}, 0},
+		{"quoted", `msg="disk \"sda\" full" path="/var/log app"`, map[string]any{"msg": `disk "sda" full`, "path": "/var/log app"}, 0},
+		{"bare key and empty value", "debug  err= \tid=7", map[string]any{"debug": true, "err": "", "id": "7"}, 0},
+		{"unterminated quote", `a=1 msg="oops`, nil, 9},
+		{"missing key", `a=1 =2`, nil, 5},
+		{"junk after quote", `msg="a"b`, nil, 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, col, err := parseLogfmtLine(tt.line)
+			if tt.want == nil {
+				if err == nil || col != tt.col {
+					t.Fatalf("expected error at column %d, got col=%d err=%v", tt.col, col, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogfmtIterator(t *testing.T) {
+	data := "ts=1 level=info\n\nts=2 msg=\"bad\n ts=3 level=error\r\n"
+	it, err := NewRowIterator(strings.NewReader(data), Logfmt, ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if row, err := it.Next(); err != nil || row.(map[string]any)["ts"] != "1" {
+		t.Fatalf("row 1: %v %v", row, err)
+	}
+	_, err = it.Next()
+	var rowErr *RowError
+	if !errors.As(err, &rowErr) || rowErr.Line != 3 || rowErr.Column != 10 {
+		t.Fatalf("expected row error on line 3, column 10, got %v", err)
+	}
+	row, err := it.Next()
+	if err != nil || !reflect.DeepEqual(row, map[string]any{"ts": "3", "level": "error"}) {
+		t.Fatalf("row 3: %v %v", row, err)
+	}
+	if _, err := it.Next(); !errors.Is(err, io.EOF) {
+		t.Fatalf("expected EOF, got %v", err)
+	}
+}
+
+func TestDetectLogfmt(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want Format
+	}{
+		{"records", "ts=2024-05-01T10:00:00Z level=info msg=\"a, b\"\nts=2024-05-01T10:00:01Z level=error\n", Logfmt},
+		{"truncated sample", "a=1 b=2\nc=3 d=", Logfmt},
+		{"single pair", "a=1,b=2\n", CSV},
+		{"bare words", "hello world\n", YAML},
+		{"yaml", "key: value=1 x=2\n", YAML},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (Detector{}).Detect([]byte(tt.data)); got != tt.want {
+				t.Fatalf("want %v got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package parse
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// markdownDelimiterRow matches the alignment row under a pipe table header,
+// such as "| --- | :---: | ---: |".
+var markdownDelimiterRow = regexp.MustCompile(`^\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?$`)
+
+// looksLikeMarkdownTable reports whether data starts with a pipe table.
+func looksLikeMarkdownTable(data []byte) bool {
+	lines := strings.SplitN(string(data), "\n", 3)
+	return len(lines) >= 2 && strings.Contains(lines[0], "|") &&
+		markdownDelimiterRow.MatchString(strings.TrimSpace(lines[1]))
+}
+
+// parseMarkdown reads the index-th GitHub-flavored pipe table of a Markdown
+// document (1-based, 0 meaning the first) into rows keyed by its header.
+// Tables inside fenced code blocks are ignored.
+func parseMarkdown(data []byte, index int) (any, error) {
+	var tables [][][]string
+	var current [][]string
+	var prev string
+	fence := ""
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if fence != "" {
+			if strings.HasPrefix(line, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "```") || strings.HasPrefix(line, "~~~") {
+			fence = line[:3]
+			if current != nil {
+				tables, current = append(tables, current), nil
+			}
+			prev = ""
+			continue
+		}
+		switch {
+		case current != nil && line != "" && strings.Contains(line, "|"):
+			current = append(current, markdownCells(line))
+		case current != nil:
+			tables, current = append(tables, current), nil
+		case strings.Contains(prev, "|") && markdownDelimiterRow.MatchString(line):
+			current = [][]string{markdownCells(prev)}
+		}
+		prev = line
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if current != nil {
+		tables = append(tables, current)
+	}
+	table, err := pickTable(len(tables), index)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := tables[table][0]
+	result := make([]map[string]any, 0, len(tables[table])-1)
+	for _, cells := range tables[table][1:] {
+		// as in GFM, missing cells are empty and excess ones are dropped
+		padded := make([]string, len(headers))
+		copy(padded, cells)
+		result = append(result, tableRow(headers, padded))
+	}
+	return result, nil
+}
+
+// markdownCells splits a table row on its unescaped pipes, dropping the
+// optional leading and trailing ones.
+func markdownCells(line string) []string {
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
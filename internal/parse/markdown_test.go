@@ -0,0 +1,92 @@
+package parse
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const markdownDoc = "# Services\n\n" +
+	"| name | port | note |\n" +
+	"|:-----|-----:|------|\n" +
+	"| api  | 8080 | a \\| b |\n" +
+	"| db   | 5432 |\n" +
+	"\n" +
+	"```\n| x | y |\n|---|---|\n| 1 | 2 |\n```\n\n" +
+	"Owners:\n\n" +
+	"team | lead\n" +
+	"--- | ---\n" +
+	"core | ana\n"
+
+func TestParseMarkdown(t *testing.T) {
+	got, err := Parse([]byte(markdownDoc), Markdown, ParseOptions{})
+	if err != nil {
+		t.Fatalf("parse markdown: %v", err)
+	}
+	want := []map[string]any{
+		{"name": "api", "port": "8080", "note": "a | b"},
+		{"name": "db", "port": "5432", "note": ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got  %#v\nwant %#v", got, want)
+	}
+
+	// the table in the code block is not counted
+	got, err = Parse([]byte(markdownDoc), Markdown, ParseOptions{TableIndex: 2})
+	if err != nil {
+		t.Fatalf("parse markdown: %v", err)
+	}
+	if !reflect.DeepEqual(got, []map[string]any{{"team": "core", "lead": "ana"}}) {
+		t.Fatalf("table 2: got %#v", got)
+	}
+
+	_, err = Parse([]byte(markdownDoc), Markdown, ParseOptions{TableIndex: 3})
+	if err == nil || !strings.Contains(err.Error(), "table 3 not found; input has 2 tables") {
+		t.Fatalf("expected missing table error, got %v", err)
+	}
+	if _, err := Parse([]byte("no tables here\n"), Markdown, ParseOptions{}); err == nil {
+		t.Fatal("expected error for input without tables")
+	}
+}
+
+func TestMarkdownCells(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{"| a | b |", []string{"a", "b"}},
+		{"a | b", []string{"a", "b"}},
+		{`| x\|y | z \|`, []string{"x|y", "z |"}},
+		{"| | b |", []string{"", "b"}},
+	}
+	for _, tt := range tests {
+		if got := markdownCells(tt.line); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("markdownCells(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestDetectTables(t *testing.T) {
+	tests := []struct {
+		name string
+		d    Detector
+		data string
+		want Format
+	}{
+		{"markdown sniff", Detector{}, "| a | b |\n|---|---|\n| 1 | 2 |\n", Markdown},
+		{"pipe csv", Detector{}, "a|b\n1|2\n", CSV},
+		{"markdown ext", Detector{FilePath: "README.md"}, "", Markdown},
+		{"html sniff", Detector{}, "<!DOCTYPE html><html><body></body></html>", HTML},
+		{"html fragment", Detector{}, "<table><tr><td>1</td></tr></table>", HTML},
+		{"xml", Detector{}, "<catalog><book/></catalog>", XML},
+		{"html content type", Detector{ContentType: "text/html; charset=utf-8"}, "", HTML},
+		{"md explicit alias", Detector{Explicit: "md"}, "", Markdown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.Detect([]byte(tt.data)); got != tt.want {
+				t.Fatalf("want %v got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package parse
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"unicode/utf8"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// parseMsgpack decodes a MessagePack value, or a stream of concatenated
+// values such as logged frames, which are returned as an array.
+func parseMsgpack(data []byte) (any, error) {
+	r := bytes.NewReader(data)
+	dec := msgpack.NewDecoder(r)
+	dec.SetMapDecoder(func(d *msgpack.Decoder) (any, error) {
+		return d.DecodeUntypedMap()
+	})
+	var docs []any
+	for r.Len() > 0 {
+		v, err := dec.DecodeInterface()
+		if err != nil {
+			// the decoder reports a value cut short as a plain EOF
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+		docs = append(docs, binaryValue(v))
+	}
+	if len(docs) == 1 {
+		return docs[0], nil
+	}
+	return docs, nil
+}
+
+// binaryValue converts the values of binary formats to the shapes of the
+// text formats: maps get string keys, byte strings become text, or base64
+// when they are not valid UTF-8, and big integers become numbers.
+func binaryValue(v any) any {
+	switch t := v.(type) {
+	case map[any]any:
+		m := ToStringKeyMap(t)
+		for k, vv := range m {
+			m[k] = binaryValue(vv)
+		}
+		return m
+	case map[string]any:
+		for k, vv := range t {
+			t[k] = binaryValue(vv)
+		}
+		return t
+	case []any:
+		for i := range t {
+			t[i] = binaryValue(t[i])
+		}
+		return t
+	case []byte:
+		if utf8.Valid(t) {
+			return string(t)
+		}
+		return base64.StdEncoding.EncodeToString(t)
+	case *big.Int:
+		return json.Number(t.String())
+	case big.Int:
+		return json.Number(t.String())
+	default:
+		return v
+	}
+}
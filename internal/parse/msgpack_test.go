@@ -0,0 +1,78 @@
+package parse
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func encodeMsgpack(t *testing.T, values ...any) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	enc := msgpack.NewEncoder(&buf)
+	for _, v := range values {
+		if err := enc.Encode(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return buf.Bytes()
+}
+
+func TestParseMsgpack(t *testing.T) {
+	data := encodeMsgpack(t, map[string]any{
+		"name":  "svc",
+		"codes": map[int]string{200: "ok", 500: "error"},
+		"raw":   []byte{0xff, 0x00},
+		"tags":  []string{"a", "b"},
+	})
+	got, err := Parse(data, MsgPack, ParseOptions{})
+	if err != nil {
+		t.Fatalf("parse msgpack: %v", err)
+	}
+	want := map[string]any{
+		"name":  "svc",
+		"codes": map[string]any{"200": "ok", "500": "error"},
+		"raw":   "/wA=",
+		"tags":  []any{"a", "b"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("mismatch:\n got  %#v\n want %#v", got, want)
+	}
+}
+
+func TestParseMsgpackFrames(t *testing.T) {
+	data := encodeMsgpack(t, map[string]any{"id": 1}, map[string]any{"id": 2})
+	got, err := Parse(data, MsgPack, ParseOptions{})
+	if err != nil {
+		t.Fatalf("parse msgpack: %v", err)
+	}
+	arr, ok := got.([]any)
+	if !ok || len(arr) != 2 || !ArrayIsObjects(arr) {
+		t.Fatalf("expected two objects, got %#v", got)
+	}
+	if _, err := Parse(data[:len(data)-1], MsgPack, ParseOptions{}); err == nil {
+		t.Fatal("expected error for truncated frame")
+	}
+}
+
+func TestDetectBinaryFormats(t *testing.T) {
+	tests := []struct {
+		d    Detector
+		data []byte
+		want Format
+	}{
+		{Detector{FilePath: "frames.msgpack"}, nil, MsgPack},
+		{Detector{FilePath: "events.cbor.gz"}, nil, CBOR},
+		{Detector{ContentType: "application/x-msgpack"}, nil, MsgPack},
+		{Detector{ContentType: "application/cbor"}, nil, CBOR},
+		{Detector{}, []byte{0xd9, 0xd9, 0xf7, 0xa0}, CBOR},
+		{Detector{Explicit: "msgpack"}, []byte("{}"), MsgPack},
+	}
+	for _, tt := range tests {
+		if got := tt.d.Detect(tt.data); got != tt.want {
+			t.Errorf("%+v: detected %s, want %s", tt.d, got, tt.want)
+		}
+	}
+}
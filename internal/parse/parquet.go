@@ -0,0 +1,186 @@
+package parse
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+// parquetMagic starts and ends every Parquet file.
+var parquetMagic = []byte("PAR1")
+
+// parseParquet reads the rows of a Parquet file into maps. Nested groups
+// become nested maps and repeated fields become arrays, so the rows flatten
+// like JSON objects. When columns is not empty, only those top-level columns
+// are decoded.
+func parseParquet(data []byte, columns []string) (any, error) {
+	f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	schema := f.Schema()
+	group := parquet.Group{}
+	for _, field := range projectParquet(schema.Fields(), columns) {
+		group[field.Name()] = plainParquet(field)
+	}
+	// The reader reconstructs the rows into plain groups, which parquetValue
+	// then shapes using the logical types of the file schema.
+	r := parquet.NewReader(f, parquet.NewSchema(schema.Name(), group))
+	defer func() { _ = r.Close() }()
+
+	rows := []any{}
+	for {
+		row := make(map[string]any)
+		if err := r.Read(&row); err != nil {
+			if errors.Is(err, io.EOF) {
+				return rows, nil
+			}
+			return nil, err
+		}
+		rows = append(rows, parquetValue(schema, row))
+	}
+}
+
+// projectParquet returns the fields named in columns, or all fields when
+// columns is empty. Names missing from the file are ignored.
+func projectParquet(fields []parquet.Field, columns []string) []parquet.Field {
+	if len(columns) == 0 {
+		return fields
+	}
+	want := make(map[string]bool, len(columns))
+	for _, c := range columns {
+		want[c] = true
+	}
+	var out []parquet.Field
+	for _, field := range fields {
+		if want[field.Name()] {
+			out = append(out, field)
+		}
+	}
+	return out
+}
+
+// plainParquet copies node without the LIST and MAP annotations of its
+// groups, so they decode as nested maps of their physical layout.
+func plainParquet(node parquet.Node) parquet.Node {
+	if node.Leaf() {
+		return node
+	}
+	group := parquet.Group{}
+	for _, field := range node.Fields() {
+		group[field.Name()] = plainParquet(field)
+	}
+	switch {
+	case node.Optional():
+		return parquet.Optional(group)
+	case node.Repeated():
+		return parquet.Repeated(group)
+	default:
+		return group
+	}
+}
+
+// parquetValue converts a decoded value using the schema node it was read
+// from: LIST and MAP groups become arrays and maps, timestamps and dates
+// become time.Time, and strings stored as bytes become strings.
+func parquetValue(node parquet.Node, v any) any {
+	if v == nil {
+		return nil
+	}
+	if arr, ok := v.([]any); ok && node.Repeated() {
+		out := make([]any, len(arr))
+		for i, item := range arr {
+			out[i] = parquetValue(parquet.Required(node), item)
+		}
+		return out
+	}
+	if node.Leaf() {
+		return parquetLeaf(node.Type().LogicalType(), v)
+	}
+	m, ok := v.(map[string]any)
+	if !ok {
+		return v
+	}
+
+	lt := node.Type().LogicalType()
+	fields := node.Fields()
+	switch {
+	case lt != nil && lt.List != nil && len(fields) == 1:
+		// a repeated group wrapping the element, or a repeated element
+		repeated := fields[0]
+		items, _ := m[repeated.Name()].([]any)
+		out := make([]any, len(items))
+		for i, item := range items {
+			if elems := repeated.Fields(); !repeated.Leaf() && len(elems) == 1 {
+				if wrapper, ok := item.(map[string]any); ok {
+					item = parquetValue(elems[0], wrapper[elems[0].Name()])
+				}
+			} else {
+				item = parquetValue(parquet.Required(repeated), item)
+			}
+			out[i] = item
+		}
+		return out
+	case lt != nil && lt.Map != nil && len(fields) == 1 && len(fields[0].Fields()) == 2:
+		// a repeated key_value group
+		keyValue := fields[0]
+		key, value := keyValue.Fields()[0], keyValue.Fields()[1]
+		items, _ := m[keyValue.Name()].([]any)
+		out := make(map[string]any, len(items))
+		for _, item := range items {
+			entry, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			k := fmt.Sprint(parquetValue(key, entry[key.Name()]))
+			out[k] = parquetValue(value, entry[value.Name()])
+		}
+		return out
+	}
+
+	for _, field := range fields {
+		if item, ok := m[field.Name()]; ok {
+			m[field.Name()] = parquetValue(field, item)
+		}
+	}
+	return m
+}
+
+func parquetLeaf(lt *format.LogicalType, v any) any {
+	if lt == nil {
+		if b, ok := v.([]byte); ok {
+			return string(b)
+		}
+		return v
+	}
+	switch {
+	case lt.Timestamp != nil:
+		n, ok := v.(int64)
+		if !ok {
+			return v
+		}
+		switch unit := lt.Timestamp.Unit; {
+		case unit.Millis != nil:
+			return time.UnixMilli(n).UTC()
+		case unit.Micros != nil:
+			return time.UnixMicro(n).UTC()
+		default:
+			return time.Unix(0, n).UTC()
+		}
+	case lt.Date != nil:
+		if days, ok := v.(int32); ok {
+			return time.Unix(int64(days)*24*60*60, 0).UTC()
+		}
+	case lt.UTF8 != nil, lt.Enum != nil, lt.Json != nil:
+		if b, ok := v.([]byte); ok {
+			return string(b)
+		}
+	}
+	return v
+}
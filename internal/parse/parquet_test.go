@@ -0,0 +1,122 @@
+package parse
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+type parquetAddress struct {
+	City string `parquet:"city"`
+	Zip  string `parquet:"zip"`
+}
+
+type parquetPerson struct {
+	Name    string            `parquet:"name"`
+	Age     int64             `parquet:"age"`
+	Nick    *string           `parquet:"nick,optional"`
+	Joined  time.Time         `parquet:"joined,timestamp(millisecond)"`
+	Born    int32             `parquet:"born,date"`
+	Tags    []string          `parquet:"tags,list"`
+	Address parquetAddress    `parquet:"address"`
+	Labels  map[string]string `parquet:"labels"`
+}
+
+// buildParquet returns a Parquet file holding two people.
+func buildParquet(t *testing.T) []byte {
+	t.Helper()
+	nick := "al"
+	people := []parquetPerson{
+		{
+			Name: "Alice", Age: 30, Nick: &nick,
+			Joined:  time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC),
+			Born:    int32(time.Date(1994, 5, 6, 0, 0, 0, 0, time.UTC).Unix() / 86400),
+			Tags:    []string{"admin", "ops"},
+			Address: parquetAddress{City: "Paris", Zip: "75001"},
+			Labels:  map[string]string{"team": "core"},
+		},
+		{
+			Name: "Bob", Age: 25,
+			Joined:  time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+			Address: parquetAddress{City: "Oslo"},
+		},
+	}
+	var buf bytes.Buffer
+	w := parquet.NewGenericWriter[parquetPerson](&buf)
+	if _, err := w.Write(people); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestParseParquet(t *testing.T) {
+	got, err := Parse(buildParquet(t), Parquet, ParseOptions{})
+	if err != nil {
+		t.Fatalf("parse parquet: %v", err)
+	}
+	rows, ok := got.([]any)
+	if !ok || len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %#v", got)
+	}
+	alice := rows[0].(map[string]any)
+	want := map[string]any{
+		"name":    "Alice",
+		"age":     int64(30),
+		"nick":    "al",
+		"joined":  time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC),
+		"born":    time.Date(1994, 5, 6, 0, 0, 0, 0, time.UTC),
+		"tags":    []any{"admin", "ops"},
+		"address": map[string]any{"city": "Paris", "zip": "75001"},
+		"labels":  map[string]any{"team": "core"},
+	}
+	if !reflect.DeepEqual(alice, want) {
+		t.Fatalf("row 0 mismatch:\n got  %#v\n want %#v", alice, want)
+	}
+	bob := rows[1].(map[string]any)
+	if bob["nick"] != nil {
+		t.Fatalf("expected nil nick for missing optional value, got %#v", bob["nick"])
+	}
+	if _, ok := bob["address"].(map[string]any); !ok {
+		t.Fatalf("expected nested address map, got %#v", bob["address"])
+	}
+}
+
+func TestParseParquetColumns(t *testing.T) {
+	got, err := Parse(buildParquet(t), Parquet, ParseOptions{Columns: []string{"name", "address", "missing"}})
+	if err != nil {
+		t.Fatalf("parse parquet: %v", err)
+	}
+	row := got.([]any)[0].(map[string]any)
+	want := map[string]any{
+		"name":    "Alice",
+		"address": map[string]any{"city": "Paris", "zip": "75001"},
+	}
+	if !reflect.DeepEqual(row, want) {
+		t.Fatalf("projected row mismatch:\n got  %#v\n want %#v", row, want)
+	}
+}
+
+func TestParseParquetInvalid(t *testing.T) {
+	if _, err := Parse([]byte("PAR1 not really"), Parquet, ParseOptions{}); err == nil {
+		t.Fatal("expected error for truncated parquet data")
+	}
+}
+
+func TestDetectParquet(t *testing.T) {
+	data := buildParquet(t)
+	if f := (Detector{}).Detect(data); f != Parquet {
+		t.Fatalf("sniffed %s, want parquet", f)
+	}
+	if f := (Detector{FilePath: "export.pq"}).Detect(nil); f != Parquet {
+		t.Fatalf("detected %s from extension, want parquet", f)
+	}
+	if f := (Detector{Explicit: "parquet"}).Detect(nil); f != Parquet {
+		t.Fatalf("explicit format gave %s, want parquet", f)
+	}
+}
@@ -1,32 +1,60 @@
 package parse
 
 import (
+	"bufio"
 	"bytes"
-	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"mime"
+	"regexp"
 	"strings"
 
 	"github.com/tidwall/jsonc"
 	"gopkg.in/yaml.v3"
+
+	"github.com/sriharip316/tablo/internal/sourcepath"
 )
 
 type Format string
 
 const (
-	Auto  Format = "auto"
-	JSON  Format = "json"
-	YAML  Format = "yaml"
-	YML   Format = "yml"
-	CSV   Format = "csv"
-	JSONL Format = "jsonl"
+	Auto     Format = "auto"
+	JSON     Format = "json"
+	YAML     Format = "yaml"
+	YML      Format = "yml"
+	CSV      Format = "csv"
+	JSONL    Format = "jsonl"
+	TOML     Format = "toml"
+	XML      Format = "xml"
+	TSV      Format = "tsv"
+	XLSX     Format = "xlsx"
+	Parquet  Format = "parquet"
+	MsgPack  Format = "msgpack"
+	CBOR     Format = "cbor"
+	Logfmt   Format = "logfmt"
+	Regex    Format = "regex"
+	Columns  Format = "columns"
+	Markdown Format = "markdown"
+	HTML     Format = "html"
 )
 
+// Binary reports whether format f is a binary encoding rather than text,
+// so that no character encoding applies to it.
+func Binary(f Format) bool {
+	switch f {
+	case XLSX, Parquet, MsgPack, CBOR:
+		return true
+	default:
+		return false
+	}
+}
+
 type Detector struct {
-	Explicit string
-	FilePath string
+	Explicit    string
+	FilePath    string
+	ContentType string // Content-Type of a fetched URL, if any
 }
 
 func (d Detector) Detect(data []byte) Format {
@@ -38,38 +66,102 @@ func (d Detector) Detect(data []byte) Format {
 			return YAML
 		case "csv":
 			return CSV
+		case "tsv":
+			return TSV
+		case "xlsx":
+			return XLSX
+		case "parquet":
+			return Parquet
+		case "msgpack":
+			return MsgPack
+		case "cbor":
+			return CBOR
+		case "logfmt":
+			return Logfmt
+		case "regex":
+			return Regex
+		case "columns":
+			return Columns
+		case "markdown", "md":
+			return Markdown
+		case "html":
+			return HTML
 		case "jsonl":
 			return JSONL
+		case "toml":
+			return TOML
+		case "xml":
+			return XML
 		}
 	}
-	// by extension
-	low := strings.ToLower(d.FilePath)
+	// by extension, looking through compression suffixes such as .gz
+	low := strings.ToLower(sourcepath.TrimCompressionExt(sourcepath.URLPath(d.FilePath)))
 	if strings.HasSuffix(low, ".json") || strings.HasSuffix(low, ".jsonc") {
 		return JSON
 	}
 	if strings.HasSuffix(low, ".yaml") || strings.HasSuffix(low, ".yml") {
 		return YAML
 	}
+	if strings.HasSuffix(low, ".jsonl") || strings.HasSuffix(low, ".ndjson") {
+		return JSONL
+	}
 	if strings.HasSuffix(low, ".csv") {
 		return CSV
 	}
+	if strings.HasSuffix(low, ".tsv") || strings.HasSuffix(low, ".tab") {
+		return TSV
+	}
+	if strings.HasSuffix(low, ".toml") {
+		return TOML
+	}
+	if strings.HasSuffix(low, ".xml") {
+		return XML
+	}
+	if strings.HasSuffix(low, ".xlsx") || strings.HasSuffix(low, ".xlsm") {
+		return XLSX
+	}
+	if strings.HasSuffix(low, ".parquet") || strings.HasSuffix(low, ".pq") {
+		return Parquet
+	}
+	if strings.HasSuffix(low, ".md") || strings.HasSuffix(low, ".markdown") {
+		return Markdown
+	}
+	if strings.HasSuffix(low, ".html") || strings.HasSuffix(low, ".htm") {
+		return HTML
+	}
+	if strings.HasSuffix(low, ".msgpack") {
+		return MsgPack
+	}
+	if strings.HasSuffix(low, ".cbor") {
+		return CBOR
+	}
+	// by media type
+	if f, ok := formatFromContentType(d.ContentType); ok {
+		return f
+	}
 	// sniff
+	if bytes.HasPrefix(data, parquetMagic) {
+		return Parquet
+	}
+	if bytes.HasPrefix(data, cborSelfDescribe) {
+		return CBOR
+	}
+	if looksLikeXLSX(data) {
+		return XLSX
+	}
+	if looksLikeTOML(data) {
+		return TOML
+	}
 	trim := bytes.TrimLeft(data, " \t\r\n")
+	if len(trim) > 0 && trim[0] == '<' {
+		if looksLikeHTML(trim) {
+			return HTML
+		}
+		return XML
+	}
 	if len(trim) > 0 && (trim[0] == '{' || trim[0] == '[') {
-		// Check if this might be JSONL (multiple lines)
-		lines := strings.Split(string(trim), "\n")
-		if len(lines) > 1 {
-			allJSON := true
-			for _, line := range lines {
-				line = strings.TrimSpace(line)
-				if line != "" && !strings.HasPrefix(line, "{") && !strings.HasPrefix(line, "[") {
-					allJSON = false
-					break
-				}
-			}
-			if allJSON {
-				return JSONL
-			}
+		if looksLikeJSONL(trim) {
+			return JSONL
 		}
 		return JSON
 	}
@@ -82,18 +174,109 @@ func (d Detector) Detect(data []byte) Format {
 			return JSON
 		}
 	}
+	if looksLikeMarkdownTable(trim) {
+		return Markdown
+	}
+	if looksLikeLogfmt(trim) {
+		return Logfmt
+	}
 	// Check for CSV by looking for comma-separated values in first line
 	if len(trim) > 0 {
 		firstLine := strings.Split(string(trim), "\n")[0]
 		if strings.Contains(firstLine, ",") && !strings.Contains(firstLine, "{") && !strings.Contains(firstLine, ":") {
 			return CSV
 		}
+		// Semicolon, tab or pipe separated values, as exported in many locales
+		if d, ok := sniffDelimited(trim); ok {
+			if d == '\t' {
+				return TSV
+			}
+			return CSV
+		}
 	}
 	return YAML
 }
 
+// looksLikeJSONL reports whether data, which may be a truncated sample,
+// holds one JSON value per line. Only the shape of a document spread over
+// several lines rules it out: a first line that does not close the value it
+// opens, or lines ending in a comma as the elements of a pretty-printed
+// array do. Lines that are not valid JSON are left for the parser to report.
+func looksLikeJSONL(data []byte) bool {
+	lines := strings.Split(string(data), "\n")
+	if len(lines) < 2 {
+		return false
+	}
+	if first := strings.TrimSpace(lines[0]); !strings.HasSuffix(first, "}") && !strings.HasSuffix(first, "]") {
+		return false
+	}
+	for _, line := range lines[:len(lines)-1] {
+		if strings.HasSuffix(strings.TrimSpace(line), ",") {
+			return false
+		}
+	}
+	return true
+}
+
+// formatFromContentType maps a Content-Type header to a format. Generic
+// types such as text/plain are not recognized.
+func formatFromContentType(contentType string) (Format, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", false
+	}
+	switch mediaType {
+	case "application/x-ndjson", "application/jsonl", "application/x-jsonlines":
+		return JSONL, true
+	case "text/csv":
+		return CSV, true
+	case "text/tab-separated-values":
+		return TSV, true
+	case "application/msgpack", "application/x-msgpack", "application/vnd.msgpack":
+		return MsgPack, true
+	case "text/html", "application/xhtml+xml":
+		return HTML, true
+	case "text/markdown", "text/x-markdown":
+		return Markdown, true
+	case "application/cbor":
+		return CBOR, true
+	case "application/vnd.apache.parquet":
+		return Parquet, true
+	case "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":
+		return XLSX, true
+	case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+		return YAML, true
+	case "application/toml":
+		return TOML, true
+	case "application/xml", "text/xml":
+		return XML, true
+	}
+	if mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") {
+		return JSON, true
+	}
+	if strings.HasSuffix(mediaType, "+xml") {
+		return XML, true
+	}
+	return "", false
+}
+
 type ParseOptions struct {
-	CSVNoHeader bool
+	CSVNoHeader   bool
+	CSVDelimiter  rune // 0 = sniff from the header line
+	CSVComment    rune // 0 = no comments
+	CSVLazyQuotes bool
+	CSVTrimSpace  bool
+	CSVSkipRows   int                   // preamble lines before the header
+	CSVInferTypes bool                  // convert cells to numbers, bools, nil and timestamps
+	CSVTypes      map[string]ColumnType // per-column types, overriding inference
+	XMLRowPath    string                // dotted path of the XML elements to use as rows
+	TOMLDocument  bool                  // keep a TOML array of tables inside its document
+	Transcoded    bool                  // input already converted to UTF-8 from a given encoding
+	Sheet         string                // xlsx worksheet name or 1-based index
+	Columns       []string              // top-level Parquet columns to decode; nil = all
+	Pattern       *regexp.Regexp        // line pattern of the regex format
+	TableIndex    int                   // 1-based Markdown or HTML table; 0 = first
+	JSONLArrays   bool                  // yield a JSONL line holding an array as one row
 }
 
 func Parse(data []byte, f Format, opts ParseOptions) (any, error) {
@@ -103,9 +286,36 @@ func Parse(data []byte, f Format, opts ParseOptions) (any, error) {
 	case YAML, YML, Auto:
 		return parseYAML(data)
 	case CSV:
-		return parseCSV(data, opts.CSVNoHeader)
+		return parseCSV(data, opts)
+	case TSV:
+		if opts.CSVDelimiter == 0 {
+			opts.CSVDelimiter = '\t'
+		}
+		return parseCSV(data, opts)
 	case JSONL:
 		return parseJSONL(data)
+	case TOML:
+		return parseTOML(data, opts.TOMLDocument)
+	case XML:
+		return parseXML(data, opts)
+	case XLSX:
+		return parseXLSX(data, opts.Sheet, opts.CSVNoHeader)
+	case Parquet:
+		return parseParquet(data, opts.Columns)
+	case Logfmt:
+		return Collect(&logfmtIterator{r: bufio.NewReader(bytes.NewReader(data))})
+	case Columns:
+		return parseColumns(data)
+	case Markdown:
+		return parseMarkdown(data, opts.TableIndex)
+	case HTML:
+		return parseHTML(data, opts.TableIndex)
+	case Regex:
+		return Collect(&regexIterator{r: bufio.NewReader(bytes.NewReader(data)), re: opts.Pattern})
+	case MsgPack:
+		return parseMsgpack(data)
+	case CBOR:
+		return parseCBOR(data)
 	default:
 		return nil, ErrInvalidFormat
 	}
@@ -145,74 +355,20 @@ func parseJSON(data []byte) (any, error) {
 	if err := dec.Decode(&v); err != nil {
 		return nil, err
 	}
-	return normalize(v), nil
-}
-
-// parseCSV converts CSV data to []map[string]any
-func parseCSV(data []byte, noHeader bool) (any, error) {
-	reader := csv.NewReader(strings.NewReader(string(data)))
-	records, err := reader.ReadAll()
-	if err != nil {
+	// a document holds a single value; anything after it is an error
+	if _, err := dec.Token(); err == nil {
+		return nil, fmt.Errorf("unexpected data after top-level value at offset %d", dec.InputOffset())
+	} else if !errors.Is(err, io.EOF) {
 		return nil, err
 	}
-	if len(records) == 0 {
-		return []map[string]any{}, nil
-	}
-
-	var headers []string
-	var startRow int
-
-	if noHeader {
-		// Generate column headers as col0, col1, etc.
-		headers = make([]string, len(records[0]))
-		for i := range headers {
-			headers[i] = fmt.Sprintf("col%d", i)
-		}
-		startRow = 0
-	} else {
-		headers = records[0]
-		startRow = 1
-	}
-
-	var result []map[string]any
-	for i := startRow; i < len(records); i++ {
-		row := records[i]
-		obj := make(map[string]any)
-		for j, value := range row {
-			if j < len(headers) {
-				obj[headers[j]] = value
-			}
-		}
-		result = append(result, obj)
-	}
-	return result, nil
+	return normalize(v), nil
 }
 
 var ErrInvalidFormat = errors.New("invalid format")
 
 // parseJSONL converts JSON Lines data to []any
 func parseJSONL(data []byte) (any, error) {
-	lines := strings.Split(string(data), "\n")
-	var result []any
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" {
-			continue
-		}
-		var v any
-		if err := json.Unmarshal([]byte(line), &v); err != nil {
-			return nil, err
-		}
-		// If the parsed value is an array, flatten it into individual objects
-		if arr, ok := v.([]any); ok {
-			for _, item := range arr {
-				result = append(result, normalize(item))
-			}
-		} else {
-			result = append(result, normalize(v))
-		}
-	}
-	return result, nil
+	return Collect(&jsonlIterator{r: bufio.NewReader(bytes.NewReader(data))})
 }
 
 // normalize YAML maps/ints etc.
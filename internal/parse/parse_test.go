@@ -2,6 +2,7 @@ package parse
 
 import (
 	"encoding/json"
+	"fmt"
 	"reflect"
 	"strings"
 	"testing"
@@ -129,7 +130,7 @@ func Test_parseCSV(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := parseCSV(tt.data, tt.noHeader)
+			got, err := parseCSV(tt.data, ParseOptions{CSVNoHeader: tt.noHeader})
 			if (err != nil) != tt.wantErr {
 				t.Fatalf("parseCSV() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -182,6 +183,27 @@ func TestDetect_SniffJSONWithLeadingSpaces(t *testing.T) {
 	}
 }
 
+func TestDetect_TruncatedSampleOfJSONArray(t *testing.T) {
+	const sampleSize = 64 * 1024 // as peeked by the app
+	var b strings.Builder
+	b.WriteString("[\n")
+	for i := 0; b.Len() < sampleSize; i++ {
+		fmt.Fprintf(&b, "  {\"id\": %d, \"name\": \"row\"},\n", i)
+	}
+	sample := []byte(b.String()[:sampleSize])
+	if got := (Detector{}).Detect(sample); got != JSON {
+		t.Fatalf("want JSON got %v", got)
+	}
+	// without the opening line, the trailing commas are still not JSONL
+	rows := sample[2:]
+	if got := (Detector{}).Detect(rows); got != JSON {
+		t.Fatalf("want JSON for comma-terminated lines, got %v", got)
+	}
+	if got := (Detector{}).Detect([]byte("{\"a\":1}\n{\"a\":2}\n{\"a\"")); got != JSONL {
+		t.Fatalf("want JSONL for a sample cut inside its last line, got %v", got)
+	}
+}
+
 func TestParse_InvalidJSON(t *testing.T) {
 	bad := []byte("{\"a\": 1")
 	if _, err := Parse(bad, JSON, ParseOptions{}); err == nil {
@@ -491,6 +513,29 @@ func TestDetect_JSONL(t *testing.T) {
 	}
 }
 
+func TestDetect_JSONLWithInvalidLine(t *testing.T) {
+	data := []byte("{\"id\": 1}\n{\"id\": 2\n{\"id\": 3}\n")
+	if got := (Detector{}).Detect(data); got != JSONL {
+		t.Fatalf("want JSONL, got %v", got)
+	}
+	for _, path := range []string{"events.jsonl", "EVENTS.NDJSON"} {
+		if got := (Detector{FilePath: path}).Detect([]byte("{\"id\": 1}")); got != JSONL {
+			t.Fatalf("%s: want JSONL, got %v", path, got)
+		}
+	}
+}
+
+func TestParse_JSONTrailingData(t *testing.T) {
+	for _, data := range []string{"{\"id\": 1}\n{\"id\": 2}\n", "[1, 2] x", "{} // done\n{}"} {
+		if _, err := Parse([]byte(data), JSON, ParseOptions{}); err == nil {
+			t.Fatalf("%q: expected error for data after the value", data)
+		}
+	}
+	if _, err := Parse([]byte("{\"id\": 1}\n// done\n"), JSON, ParseOptions{}); err != nil {
+		t.Fatalf("unexpected error for a trailing comment: %v", err)
+	}
+}
+
 func TestParse_JSONL(t *testing.T) {
 	data := []byte(`{"id": 1}
 {"id": 2}`)
@@ -559,3 +604,45 @@ func TestParse_JSONLWithArrays(t *testing.T) {
 		}
 	}
 }
+
+func TestDetect_CompressedExtension(t *testing.T) {
+	tests := map[string]Format{
+		"users.csv.gz":     CSV,
+		"data.json.zst":    JSON,
+		"config.yaml.bz2":  YAML,
+		"archive.JSONC.XZ": JSON,
+	}
+	for path, want := range tests {
+		d := Detector{FilePath: path}
+		if got := d.Detect([]byte("a: 1")); got != want {
+			t.Errorf("Detect(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestDetect_ContentType(t *testing.T) {
+	tests := []struct {
+		contentType string
+		want        Format
+	}{
+		{"application/json; charset=utf-8", JSON},
+		{"application/vnd.github+json", JSON},
+		{"application/x-ndjson", JSONL},
+		{"text/csv", CSV},
+		{"application/yaml", YAML},
+		{"text/plain", CSV}, // generic type falls back to sniffing
+	}
+	for _, tt := range tests {
+		d := Detector{ContentType: tt.contentType}
+		if got := d.Detect([]byte("a,b\n1,2")); got != tt.want {
+			t.Errorf("Detect with %q = %v, want %v", tt.contentType, got, tt.want)
+		}
+	}
+}
+
+func TestDetect_URLExtension(t *testing.T) {
+	d := Detector{FilePath: "https://example.com/export/users.csv?page=2", ContentType: "application/octet-stream"}
+	if got := d.Detect([]byte(`{"a":1}`)); got != CSV {
+		t.Fatalf("want CSV from URL path, got %v", got)
+	}
+}
@@ -0,0 +1,101 @@
+package parse
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// PatternPresets are named line patterns for common log formats.
+var PatternPresets = map[string]string{
+	// nginx's default "combined" log_format
+	"nginx-combined": `^(?P<remote_addr>\S+) - (?P<remote_user>\S+) \[(?P<time_local>[^\]]+)\] "(?P<request>[^"]*)" (?P<status>\d{3}) (?P<body_bytes_sent>\d+|-) "(?P<http_referer>[^"]*)" "(?P<http_user_agent>[^"]*)"`,
+	// Apache's Common Log Format
+	"apache-common": `^(?P<host>\S+) (?P<ident>\S+) (?P<user>\S+) \[(?P<time>[^\]]+)\] "(?P<request>[^"]*)" (?P<status>\d{3}) (?P<size>\d+|-)`,
+	// Apache's Combined Log Format
+	"apache-combined": `^(?P<host>\S+) (?P<ident>\S+) (?P<user>\S+) \[(?P<time>[^\]]+)\] "(?P<request>[^"]*)" (?P<status>\d{3}) (?P<size>\d+|-) "(?P<referer>[^"]*)" "(?P<user_agent>[^"]*)"`,
+	// BSD syslog (RFC 3164) lines, with or without the <priority> prefix
+	"syslog": `^(?:<(?P<priority>\d+)>)?(?P<timestamp>[A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2}) (?P<host>\S+) (?P<program>[^:\[\s]+)(?:\[(?P<pid>\d+)\])?: (?P<message>.*)`,
+}
+
+// errNoMatch is reported for lines that do not match the pattern.
+var errNoMatch = errors.New("line does not match pattern")
+
+// CompilePattern compiles a line pattern given as a preset name or as a
+// regular expression. The pattern must have at least one named group, as
+// named groups become the columns.
+func CompilePattern(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, errors.New("no pattern given")
+	}
+	if preset, ok := PatternPresets[strings.ToLower(pattern)]; ok {
+		pattern = preset
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range re.SubexpNames() {
+		if name != "" {
+			return re, nil
+		}
+	}
+	names := make([]string, 0, len(PatternPresets))
+	for name := range PatternPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return nil, fmt.Errorf("pattern has no named groups such as (?P<name>...); presets are %s", strings.Join(names, ", "))
+}
+
+// regexIterator matches each line against a pattern and yields its named
+// groups as a row. Groups that did not take part in the match are nil.
+// Lines that do not match are reported as RowErrors.
+type regexIterator struct {
+	r    *bufio.Reader
+	re   *regexp.Regexp
+	done bool
+	line int
+}
+
+func (it *regexIterator) Next() (any, error) {
+	if it.re == nil {
+		return nil, errors.New("no pattern given")
+	}
+	for !it.done {
+		raw, err := it.r.ReadString('\n')
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return nil, err
+			}
+			it.done = true
+		}
+		it.line++
+		line := strings.TrimRight(raw, "\r\n")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		loc := it.re.FindStringSubmatchIndex(line)
+		if loc == nil {
+			return nil, &RowError{Line: it.line, Snippet: snippet(line), Err: errNoMatch}
+		}
+		row := make(map[string]any)
+		for i, name := range it.re.SubexpNames() {
+			if name == "" {
+				continue
+			}
+			if loc[2*i] >= 0 {
+				row[name] = line[loc[2*i]:loc[2*i+1]]
+			} else if _, ok := row[name]; !ok {
+				// a group name may be repeated in alternatives
+				row[name] = nil
+			}
+		}
+		return row, nil
+	}
+	return nil, io.EOF
+}
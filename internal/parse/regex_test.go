@@ -0,0 +1,108 @@
+package parse
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCompilePattern(t *testing.T) {
+	for name := range PatternPresets {
+		if _, err := CompilePattern(name); err != nil {
+			t.Errorf("preset %s: %v", name, err)
+		}
+	}
+	if _, err := CompilePattern("Nginx-Combined"); err != nil {
+		t.Errorf("preset names should be case-insensitive: %v", err)
+	}
+	if _, err := CompilePattern(`(\d+)`); err == nil || !strings.Contains(err.Error(), "no named groups") {
+		t.Errorf("expected named group error, got %v", err)
+	}
+	if _, err := CompilePattern(`(?P<a>`); err == nil {
+		t.Error("expected syntax error")
+	}
+}
+
+func TestPatternPresets(t *testing.T) {
+	tests := []struct {
+		preset string
+		line   string
+		want   map[string]any
+	}{
+		{
+			"nginx-combined",
+			`203.0.113.9 - - [01/May/2024:10:00:00 +0000] "GET /api HTTP/1.1" 502 157 "-" "curl/8.0"`,
+			map[string]any{
+				"remote_addr": "203.0.113.9", "remote_user": "-", "time_local": "01/May/2024:10:00:00 +0000",
+				"request": "GET /api HTTP/1.1", "status": "502", "body_bytes_sent": "157",
+				"http_referer": "-", "http_user_agent": "curl/8.0",
+			},
+		},
+		{
+			"apache-common",
+			`127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326`,
+			map[string]any{
+				"host": "127.0.0.1", "ident": "-", "user": "frank", "time": "10/Oct/2000:13:55:36 -0700",
+				"request": "GET /apache_pb.gif HTTP/1.0", "status": "200", "size": "2326",
+			},
+		},
+		{
+			"syslog",
+			`<34>Oct 11 22:14:15 mymachine su: 'su root' failed for lonvick on /dev/pts/8`,
+			map[string]any{
+				"priority": "34", "timestamp": "Oct 11 22:14:15", "host": "mymachine", "program": "su",
+				"pid": nil, "message": "'su root' failed for lonvick on /dev/pts/8",
+			},
+		},
+		{
+			"syslog",
+			`May  1 10:00:00 web sshd[4242]: Accepted publickey`,
+			map[string]any{
+				"priority": nil, "timestamp": "May  1 10:00:00", "host": "web", "program": "sshd",
+				"pid": "4242", "message": "Accepted publickey",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.preset, func(t *testing.T) {
+			re, err := CompilePattern(tt.preset)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := Parse([]byte(tt.line+"\n"), Regex, ParseOptions{Pattern: re})
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if !reflect.DeepEqual(got, []any{tt.want}) {
+				t.Fatalf("got  %#v\nwant %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegexIteratorNoMatch(t *testing.T) {
+	re, err := CompilePattern(`^(?P<level>[A-Z]+): (?P<msg>.*)$`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	it, err := NewRowIterator(strings.NewReader("INFO: up\n-- banner --\n\nWARN: slow\n"), Regex, ParseOptions{Pattern: re})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if row, err := it.Next(); err != nil || row.(map[string]any)["msg"] != "up" {
+		t.Fatalf("row 1: %v %v", row, err)
+	}
+	_, err = it.Next()
+	var rowErr *RowError
+	if !errors.As(err, &rowErr) || rowErr.Line != 2 || rowErr.Snippet != "-- banner --" || !errors.Is(err, errNoMatch) {
+		t.Fatalf("expected no-match error on line 2, got %v", err)
+	}
+	if row, err := it.Next(); err != nil || row.(map[string]any)["level"] != "WARN" {
+		t.Fatalf("row 4: %v %v", row, err)
+	}
+	if _, err := it.Next(); !errors.Is(err, io.EOF) {
+		t.Fatalf("expected EOF, got %v", err)
+	}
+}
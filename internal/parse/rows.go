@@ -0,0 +1,167 @@
+package parse
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// RowIterator yields parsed rows one at a time. Next returns io.EOF once
+// the underlying input is exhausted.
+type RowIterator interface {
+	Next() (any, error)
+}
+
+// RowError reports a malformed JSONL or logfmt line, a CSV record, or a line
+// not matching the regex pattern. The iterator that returned it can still be
+// advanced to the rows that follow.
+type RowError struct {
+	Line    int    // 1-based line number
+	Column  int    // 1-based column, or 0 when unknown
+	Snippet string // leading part of the offending input
+	Err     error
+}
+
+func (e *RowError) Error() string {
+	loc := fmt.Sprintf("line %d", e.Line)
+	if e.Column > 0 {
+		loc += fmt.Sprintf(", column %d", e.Column)
+	}
+	if e.Snippet != "" {
+		return fmt.Sprintf("%s: %v in %q", loc, e.Err, e.Snippet)
+	}
+	return fmt.Sprintf("%s: %v", loc, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// maxSnippet is the length at which RowError snippets are cut off.
+const maxSnippet = 40
+
+func snippet(s string) string {
+	if r := []rune(s); len(r) > maxSnippet {
+		return string(r[:maxSnippet]) + "..."
+	}
+	return s
+}
+
+// Streamable reports whether format f can be decoded row by row without
+// buffering the whole input.
+func Streamable(f Format) bool {
+	switch f {
+	case JSONL, CSV, TSV, Logfmt, Regex:
+		return true
+	default:
+		return false
+	}
+}
+
+// NewRowIterator returns a RowIterator decoding r as format f. Only
+// streamable formats are supported; see Streamable.
+func NewRowIterator(r io.Reader, f Format, opts ParseOptions) (RowIterator, error) {
+	switch f {
+	case JSONL:
+		return &jsonlIterator{r: bufio.NewReader(r), arrays: opts.JSONLArrays}, nil
+	case Logfmt:
+		return &logfmtIterator{r: bufio.NewReader(r)}, nil
+	case Regex:
+		return &regexIterator{r: bufio.NewReader(r), re: opts.Pattern}, nil
+	case CSV:
+		return &csvIterator{src: r, opts: opts}, nil
+	case TSV:
+		if opts.CSVDelimiter == 0 {
+			opts.CSVDelimiter = '\t'
+		}
+		return &csvIterator{src: r, opts: opts}, nil
+	default:
+		return nil, ErrInvalidFormat
+	}
+}
+
+// Collect drains it and returns all remaining rows.
+func Collect(it RowIterator) ([]any, error) {
+	var rows []any
+	for {
+		row, err := it.Next()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+}
+
+// jsonlIterator decodes one JSON value per line. A line holding an array
+// yields each of its elements as a separate row, unless arrays is set.
+type jsonlIterator struct {
+	r       *bufio.Reader
+	arrays  bool
+	pending []any
+	done    bool
+	line    int
+}
+
+func (it *jsonlIterator) Next() (any, error) {
+	for len(it.pending) == 0 {
+		if it.done {
+			return nil, io.EOF
+		}
+		raw, err := it.r.ReadBytes('\n')
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return nil, err
+			}
+			it.done = true
+		}
+		it.line++
+		line := bytes.TrimSpace(raw)
+		if len(line) == 0 {
+			continue
+		}
+		var v any
+		if err := json.Unmarshal(line, &v); err != nil {
+			rowErr := &RowError{Line: it.line, Snippet: snippet(string(line)), Err: err}
+			var syntaxErr *json.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				indent := len(raw) - len(bytes.TrimLeft(raw, " \t\r\n"))
+				rowErr.Column = indent + int(syntaxErr.Offset)
+			}
+			return nil, rowErr
+		}
+		if arr, ok := v.([]any); ok && !it.arrays {
+			for _, item := range arr {
+				it.pending = append(it.pending, normalize(item))
+			}
+		} else {
+			it.pending = append(it.pending, normalize(v))
+		}
+	}
+	row := it.pending[0]
+	it.pending = it.pending[1:]
+	return row, nil
+}
+
+// NewSliceIterator returns a RowIterator over already decoded rows.
+func NewSliceIterator(rows []any) RowIterator {
+	return &sliceIterator{rows: rows}
+}
+
+type sliceIterator struct {
+	rows []any
+}
+
+func (it *sliceIterator) Next() (any, error) {
+	if len(it.rows) == 0 {
+		return nil, io.EOF
+	}
+	row := it.rows[0]
+	it.rows = it.rows[1:]
+	return row, nil
+}
@@ -0,0 +1,192 @@
+package parse
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestStreamable(t *testing.T) {
+	for _, f := range []Format{JSONL, CSV} {
+		if !Streamable(f) {
+			t.Errorf("expected %s to be streamable", f)
+		}
+	}
+	for _, f := range []Format{JSON, YAML} {
+		if Streamable(f) {
+			t.Errorf("expected %s not to be streamable", f)
+		}
+	}
+}
+
+func TestNewRowIterator_InvalidFormat(t *testing.T) {
+	if _, err := NewRowIterator(strings.NewReader("{}"), JSON, ParseOptions{}); !errors.Is(err, ErrInvalidFormat) {
+		t.Fatalf("expected ErrInvalidFormat, got %v", err)
+	}
+}
+
+func TestRowIterator_JSONL(t *testing.T) {
+	data := "{\"id\": 1}\n\n[{\"id\": 2}, {\"id\": 3}]\n{\"id\": 4}"
+	it, err := NewRowIterator(strings.NewReader(data), JSONL, ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := Collect(it)
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("expected 4 rows, got %d", len(rows))
+	}
+	for i, r := range rows {
+		if r.(map[string]any)["id"] != float64(i+1) {
+			t.Fatalf("row %d: unexpected %v", i, r)
+		}
+	}
+	if _, err := it.Next(); !errors.Is(err, io.EOF) {
+		t.Fatalf("expected io.EOF after last row, got %v", err)
+	}
+}
+
+func TestRowIterator_JSONLLongLine(t *testing.T) {
+	long := strings.Repeat("x", 1<<20)
+	it, err := NewRowIterator(strings.NewReader(`{"v":"`+long+`"}`), JSONL, ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	row, err := it.Next()
+	if err != nil {
+		t.Fatalf("next: %v", err)
+	}
+	if row.(map[string]any)["v"] != long {
+		t.Fatal("long line not decoded intact")
+	}
+}
+
+func TestRowIterator_JSONLArrays(t *testing.T) {
+	data := "[[\"n\"], [1]]\n{\"n\": 2}\n"
+	it, err := NewRowIterator(strings.NewReader(data), JSONL, ParseOptions{JSONLArrays: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := Collect(it)
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d: %v", len(rows), rows)
+	}
+	if arr, ok := rows[0].([]any); !ok || len(arr) != 2 {
+		t.Fatalf("expected the first line as one array, got %v", rows[0])
+	}
+}
+
+func TestRowIterator_JSONLErrorAfterValidRows(t *testing.T) {
+	it, err := NewRowIterator(strings.NewReader("{\"id\": 1}\nnot json\n"), JSONL, ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := it.Next(); err != nil {
+		t.Fatalf("first row: %v", err)
+	}
+	if _, err := it.Next(); err == nil || errors.Is(err, io.EOF) {
+		t.Fatalf("expected decode error, got %v", err)
+	}
+}
+
+func TestRowIterator_JSONLRowError(t *testing.T) {
+	it, err := NewRowIterator(strings.NewReader("{\"id\": 1}\n\n  {\"id\": x}\n{\"id\": 3}\n"), JSONL, ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := it.Next(); err != nil {
+		t.Fatalf("first row: %v", err)
+	}
+	_, err = it.Next()
+	var rowErr *RowError
+	if !errors.As(err, &rowErr) {
+		t.Fatalf("expected RowError, got %v", err)
+	}
+	if rowErr.Line != 3 || rowErr.Column != 10 || rowErr.Snippet != `{"id": x}` {
+		t.Fatalf("unexpected location: %+v", rowErr)
+	}
+	if got := rowErr.Error(); !strings.HasPrefix(got, "line 3, column 10: invalid character 'x'") {
+		t.Fatalf("unexpected message: %s", got)
+	}
+
+	// Decoding resumes after the malformed line
+	row, err := it.Next()
+	if err != nil || row.(map[string]any)["id"] != float64(3) {
+		t.Fatalf("got %v, %v", row, err)
+	}
+}
+
+func TestRowIterator_CSVFieldCount(t *testing.T) {
+	it, err := NewRowIterator(strings.NewReader("a,b\n1,2\n3\n4,5\n"), CSV, ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := it.Next(); err != nil {
+		t.Fatalf("first row: %v", err)
+	}
+	_, err = it.Next()
+	var rowErr *RowError
+	if !errors.As(err, &rowErr) || rowErr.Line != 3 || rowErr.Snippet != "3" {
+		t.Fatalf("expected RowError on line 3, got %v", err)
+	}
+	row, err := it.Next()
+	if err != nil || row.(map[string]any)["a"] != "4" {
+		t.Fatalf("got %v, %v", row, err)
+	}
+}
+
+func TestRowError_Snippet(t *testing.T) {
+	e := &RowError{Line: 1, Snippet: snippet(strings.Repeat("x", 50)), Err: errors.New("bad")}
+	if want := `line 1: bad in "` + strings.Repeat("x", 40) + `..."`; e.Error() != want {
+		t.Fatalf("got %s", e.Error())
+	}
+}
+
+func TestRowIterator_CSV(t *testing.T) {
+	it, err := NewRowIterator(strings.NewReader("name,age\nAlice,30\nBob,25\n"), CSV, ParseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := Collect(it)
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	if rows[1].(map[string]any)["name"] != "Bob" {
+		t.Fatalf("unexpected row: %v", rows[1])
+	}
+}
+
+func TestRowIterator_CSVNoHeader(t *testing.T) {
+	it, err := NewRowIterator(strings.NewReader("Alice,30\n"), CSV, ParseOptions{CSVNoHeader: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	row, err := it.Next()
+	if err != nil {
+		t.Fatalf("next: %v", err)
+	}
+	m := row.(map[string]any)
+	if m["col0"] != "Alice" || m["col1"] != "30" {
+		t.Fatalf("unexpected row: %v", m)
+	}
+}
+
+func TestSliceIterator(t *testing.T) {
+	it := NewSliceIterator([]any{1, "two"})
+	rows, err := Collect(it)
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	if len(rows) != 2 || rows[0] != 1 || rows[1] != "two" {
+		t.Fatalf("unexpected rows: %v", rows)
+	}
+}
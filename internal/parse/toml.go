@@ -0,0 +1,97 @@
+package parse
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// tomlTableHeader matches a [table] or [[array.of.tables]] header line.
+var tomlTableHeader = regexp.MustCompile(`^\[\[?\s*[A-Za-z0-9_"'-][A-Za-z0-9_."' -]*\]\]?\s*(#.*)?$`)
+
+// parseTOML decodes a TOML document. When its only tables are a single array
+// of tables, such as a list of [[servers]], those tables are yielded as rows
+// and top-level keys beside them, such as title = "x", are dropped. Any
+// other document is returned as a map, as is every document when whole is
+// set so that --root can pick from it.
+func parseTOML(data []byte, whole bool) (any, error) {
+	var doc map[string]any
+	if _, err := toml.Decode(string(data), &doc); err != nil {
+		return nil, err
+	}
+	v := normalizeTOML(doc).(map[string]any)
+	if whole {
+		return v, nil
+	}
+	var rows []any
+	for _, val := range v {
+		switch t := val.(type) {
+		case map[string]any:
+			return v, nil
+		case []any:
+			if len(t) == 0 || !ArrayIsObjects(t) {
+				continue
+			}
+			if rows != nil {
+				return v, nil
+			}
+			rows = t
+		}
+	}
+	if rows == nil {
+		return v, nil
+	}
+	return rows, nil
+}
+
+// normalizeTOML converts the []map[string]any the decoder produces for
+// arrays of tables into []any, matching the shapes of the other formats.
+func normalizeTOML(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, vv := range t {
+			t[k] = normalizeTOML(vv)
+		}
+		return t
+	case []map[string]any:
+		out := make([]any, len(t))
+		for i := range t {
+			out[i] = normalizeTOML(t[i])
+		}
+		return out
+	case []any:
+		for i := range t {
+			t[i] = normalizeTOML(t[i])
+		}
+		return t
+	default:
+		return v
+	}
+}
+
+// looksLikeTOML reports whether the first meaningful line of data is a TOML
+// table header or a complete key = value pair.
+func looksLikeTOML(data []byte) bool {
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if tomlTableHeader.MatchString(line) {
+			// [true] or [1] is a JSON array rather than a table header
+			return !json.Valid([]byte(line))
+		}
+		if !strings.Contains(line, "=") {
+			return false
+		}
+		var kv map[string]any
+		_, err := toml.Decode(line, &kv)
+		return err == nil
+	}
+	return false
+}
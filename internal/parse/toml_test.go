@@ -0,0 +1,122 @@
+package parse
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseTOML_ArrayOfTablesAsRows(t *testing.T) {
+	data := strings.TrimSpace(`
+[[servers]]
+name = "alpha"
+port = 8080
+
+[[servers]]
+name = "beta"
+port = 8081
+[servers.tls]
+enabled = true
+`)
+	v, err := Parse([]byte(data), TOML, ParseOptions{})
+	if err != nil {
+		t.Fatalf("parse toml: %v", err)
+	}
+	rows, ok := v.([]any)
+	if !ok || len(rows) != 2 {
+		t.Fatalf("want 2 rows, got %T %v", v, v)
+	}
+	second := rows[1].(map[string]any)
+	if second["name"] != "beta" || second["port"] != int64(8081) {
+		t.Fatalf("unexpected row: %v", second)
+	}
+	if tls, ok := second["tls"].(map[string]any); !ok || tls["enabled"] != true {
+		t.Fatalf("unexpected nested table: %v", second["tls"])
+	}
+}
+
+func TestParseTOML_ArrayOfTablesBesideKeys(t *testing.T) {
+	data := strings.TrimSpace(`
+title = "x"
+ports = [1, 2]
+
+[[servers]]
+name = "alpha"
+
+[[servers]]
+name = "beta"
+`)
+	v, err := Parse([]byte(data), TOML, ParseOptions{})
+	if err != nil {
+		t.Fatalf("parse toml: %v", err)
+	}
+	if rows, ok := v.([]any); !ok || len(rows) != 2 {
+		t.Fatalf("want 2 rows, got %T %v", v, v)
+	}
+
+	v, err = Parse([]byte(data), TOML, ParseOptions{TOMLDocument: true})
+	if err != nil {
+		t.Fatalf("parse toml: %v", err)
+	}
+	if m, ok := v.(map[string]any); !ok || m["title"] != "x" {
+		t.Fatalf("want the whole document, got %T %v", v, v)
+	}
+}
+
+func TestParseTOML_Document(t *testing.T) {
+	data := strings.TrimSpace(`
+title = "config"
+
+[database]
+ports = [8000, 8001]
+
+[[servers]]
+name = "alpha"
+`)
+	v, err := Parse([]byte(data), TOML, ParseOptions{})
+	if err != nil {
+		t.Fatalf("parse toml: %v", err)
+	}
+	m, ok := v.(map[string]any)
+	if !ok {
+		t.Fatalf("want map, got %T", v)
+	}
+	if _, ok := m["servers"].([]any); !ok {
+		t.Fatalf("array of tables should be []any, got %T", m["servers"])
+	}
+	if _, ok := m["database"].(map[string]any); !ok {
+		t.Fatalf("table should be a map, got %T", m["database"])
+	}
+}
+
+func TestParseTOML_Invalid(t *testing.T) {
+	if _, err := Parse([]byte("a = "), TOML, ParseOptions{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestDetect_TOML(t *testing.T) {
+	tests := []struct {
+		name string
+		d    Detector
+		data string
+		want Format
+	}{
+		{"extension", Detector{FilePath: "Cargo.toml"}, "x: 1", TOML},
+		{"explicit", Detector{Explicit: "toml"}, "{}", TOML},
+		{"content type", Detector{ContentType: "application/toml"}, "", TOML},
+		{"table header", Detector{}, "# config\n[server]\nport = 80\n", TOML},
+		{"array of tables", Detector{}, "[[servers]]\nname = \"a\"\n", TOML},
+		{"key value", Detector{}, "title = \"x\"\n", TOML},
+		{"json array", Detector{}, "[1]", JSON},
+		{"json bool array", Detector{}, "[true]", JSON},
+		{"yaml", Detector{}, "a: b=c\n", YAML},
+		{"csv", Detector{}, "a=1,b=2\n", CSV},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.Detect([]byte(tt.data)); got != tt.want {
+				t.Fatalf("want %v got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package parse
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ColumnType is the type a CSV column is converted to.
+type ColumnType string
+
+const (
+	TypeString ColumnType = "string"
+	TypeInt    ColumnType = "int"
+	TypeFloat  ColumnType = "float"
+	TypeBool   ColumnType = "bool"
+	TypeDate   ColumnType = "date"
+)
+
+var columnTypeAliases = map[string]ColumnType{
+	"string":    TypeString,
+	"str":       TypeString,
+	"int":       TypeInt,
+	"integer":   TypeInt,
+	"float":     TypeFloat,
+	"number":    TypeFloat,
+	"bool":      TypeBool,
+	"boolean":   TypeBool,
+	"date":      TypeDate,
+	"time":      TypeDate,
+	"datetime":  TypeDate,
+	"timestamp": TypeDate,
+}
+
+// ParseColumnTypes parses a comma-separated list of column:type pairs, such
+// as "age:int,active:bool,joined:date".
+func ParseColumnTypes(spec string) (map[string]ColumnType, error) {
+	types := make(map[string]ColumnType)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		i := strings.LastIndex(part, ":")
+		if i <= 0 {
+			return nil, fmt.Errorf("%q is not in column:type form", part)
+		}
+		name, typ := strings.TrimSpace(part[:i]), strings.ToLower(strings.TrimSpace(part[i+1:]))
+		t, ok := columnTypeAliases[typ]
+		if !ok {
+			return nil, fmt.Errorf("unknown type %q for column %q (want string, int, float, bool or date)", typ, name)
+		}
+		types[name] = t
+	}
+	return types, nil
+}
+
+// jsonNumber matches the JSON number syntax. Values such as "0012" or
+// "1_000" are left as strings.
+var jsonNumber = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// timeLayouts are the timestamp layouts recognized in CSV cells.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// isNull reports whether a CSV cell stands for a missing value.
+func isNull(s string) bool {
+	return s == "" || strings.EqualFold(s, "null")
+}
+
+// inferCell converts a CSV cell to the type its text suggests: nil for
+// empty and NULL cells, bool, json.Number, time.Time, or the string itself.
+func inferCell(s string) any {
+	if isNull(s) {
+		return nil
+	}
+	switch strings.ToLower(s) {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if jsonNumber.MatchString(s) {
+		return json.Number(s)
+	}
+	if t, ok := parseTime(s); ok {
+		return t
+	}
+	return s
+}
+
+// convertCell converts a CSV cell to type t. Empty and NULL cells become nil.
+func convertCell(s string, t ColumnType) (any, error) {
+	if t == TypeString {
+		return s, nil
+	}
+	if isNull(s) {
+		return nil, nil
+	}
+	switch t {
+	case TypeInt:
+		if _, err := strconv.ParseInt(s, 10, 64); err != nil {
+			return nil, fmt.Errorf("%q is not an int", s)
+		}
+		return json.Number(s), nil
+	case TypeFloat:
+		if _, err := strconv.ParseFloat(s, 64); err != nil {
+			return nil, fmt.Errorf("%q is not a float", s)
+		}
+		return json.Number(s), nil
+	case TypeBool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a bool", s)
+		}
+		return b, nil
+	case TypeDate:
+		ts, ok := parseTime(s)
+		if !ok {
+			return nil, fmt.Errorf("%q is not a date", s)
+		}
+		return ts, nil
+	}
+	return s, nil
+}
+
+func parseTime(s string) (time.Time, bool) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
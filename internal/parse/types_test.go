@@ -0,0 +1,81 @@
+package parse
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseColumnTypes(t *testing.T) {
+	got, err := ParseColumnTypes("age:int, active:Boolean,joined:date,ratio:number,url:string")
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	want := map[string]ColumnType{"age": TypeInt, "active": TypeBool, "joined": TypeDate, "ratio": TypeFloat, "url": TypeString}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	for _, spec := range []string{"age", "age:decimal", ":int"} {
+		if _, err := ParseColumnTypes(spec); err == nil {
+			t.Fatalf("expected error for %q", spec)
+		}
+	}
+}
+
+func TestInferCell(t *testing.T) {
+	tests := []struct {
+		in   string
+		want any
+	}{
+		{"", nil},
+		{"NULL", nil},
+		{"True", true},
+		{"false", false},
+		{"42", json.Number("42")},
+		{"-3.5e2", json.Number("-3.5e2")},
+		{"0012", "0012"},
+		{"1,5", "1,5"},
+		{"NaN", "NaN"},
+		{"2024-03-01", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"2024-03-01T09:30:00Z", time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)},
+		{"hello", "hello"},
+	}
+	for _, tt := range tests {
+		if got := inferCell(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("inferCell(%q) = %#v, want %#v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseCSV_Types(t *testing.T) {
+	data := "id,age,active,joined,zip\n1,30,yes,2024-03-01,0012\n2,,true,,NULL\n"
+	got, err := Parse([]byte(data), CSV, ParseOptions{
+		CSVInferTypes: true,
+		CSVTypes:      map[string]ColumnType{"id": TypeString, "age": TypeInt, "joined": TypeDate},
+	})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	want := []map[string]any{
+		{"id": "1", "age": json.Number("30"), "active": "yes", "joined": time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), "zip": "0012"},
+		{"id": "2", "age": nil, "active": true, "joined": nil, "zip": nil},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestParseCSV_TypeError(t *testing.T) {
+	_, err := Parse([]byte("name,age\nAlice,30\nBob,old\n"), CSV, ParseOptions{CSVTypes: map[string]ColumnType{"age": TypeInt}})
+	var rowErr *RowError
+	if !errors.As(err, &rowErr) || rowErr.Line != 3 || rowErr.Column != 5 {
+		t.Fatalf("expected RowError at line 3, column 5, got %v", err)
+	}
+	if !strings.Contains(err.Error(), `column "age": "old" is not an int`) {
+		t.Fatalf("unexpected message: %v", err)
+	}
+}
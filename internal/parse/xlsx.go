@@ -0,0 +1,198 @@
+package parse
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// zipMagic starts every zip archive, and so every xlsx workbook.
+var zipMagic = []byte("PK\x03\x04")
+
+// looksLikeXLSX reports whether data is a zip archive holding a workbook.
+func looksLikeXLSX(data []byte) bool {
+	return bytes.HasPrefix(data, zipMagic) &&
+		(bytes.Contains(data, []byte("[Content_Types].xml")) || bytes.Contains(data, []byte("xl/")))
+}
+
+// parseXLSX reads one worksheet of an xlsx workbook into rows keyed by the
+// header row, or by generated col0, col1, ... names when noHeader is set.
+// The sheet is chosen by name or by 1-based index, defaulting to the first.
+func parseXLSX(data []byte, sheet string, noHeader bool) (any, error) {
+	f, err := excelize.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	name, err := resolveSheet(f.GetSheetList(), sheet)
+	if err != nil {
+		return nil, err
+	}
+	records, err := f.GetRows(name, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, err
+	}
+	props, _ := f.GetWorkbookProps()
+	c := &xlsxCells{f: f, sheet: name, date1904: props.Date1904 != nil && *props.Date1904, dateStyles: make(map[int]bool)}
+
+	result := []map[string]any{}
+	var headers []string
+	for i, record := range records {
+		if isBlankRecord(record) {
+			continue
+		}
+		if headers == nil && !noHeader {
+			headers = make([]string, len(record))
+			for j, h := range record {
+				headers[j] = strings.TrimSpace(h)
+				if headers[j] == "" {
+					headers[j] = fmt.Sprintf("col%d", j)
+				}
+			}
+			continue
+		}
+		row := make(map[string]any, len(record))
+		for j, raw := range record {
+			key := fmt.Sprintf("col%d", j)
+			if j < len(headers) {
+				key = headers[j]
+			}
+			v, err := c.value(j+1, i+1, raw)
+			if err != nil {
+				return nil, &RowError{Line: i + 1, Column: j + 1, Snippet: snippet(raw), Err: err}
+			}
+			row[key] = v
+		}
+		result = append(result, row)
+	}
+	return result, nil
+}
+
+// resolveSheet finds a sheet by name, then by 1-based index.
+func resolveSheet(sheets []string, sheet string) (string, error) {
+	if len(sheets) == 0 {
+		return "", fmt.Errorf("workbook has no sheets")
+	}
+	if sheet == "" {
+		return sheets[0], nil
+	}
+	for _, s := range sheets {
+		if s == sheet {
+			return s, nil
+		}
+	}
+	if n, err := strconv.Atoi(sheet); err == nil && n >= 1 && n <= len(sheets) {
+		return sheets[n-1], nil
+	}
+	return "", fmt.Errorf("sheet %q not found; available sheets: %s", sheet, strings.Join(sheets, ", "))
+}
+
+func isBlankRecord(record []string) bool {
+	for _, v := range record {
+		if v != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// xlsxCells types the raw values of a worksheet's cells.
+type xlsxCells struct {
+	f          *excelize.File
+	sheet      string
+	date1904   bool
+	dateStyles map[int]bool // style index -> has a date number format
+}
+
+// value converts a raw cell value: booleans to bool, numbers to json.Number,
+// numbers formatted as dates to time.Time and empty cells to nil.
+func (c *xlsxCells) value(col, row int, raw string) (any, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	cell, err := excelize.CoordinatesToCellName(col, row)
+	if err != nil {
+		return nil, err
+	}
+	typ, err := c.f.GetCellType(c.sheet, cell)
+	if err != nil {
+		return nil, err
+	}
+	switch typ {
+	case excelize.CellTypeBool:
+		return raw == "1", nil
+	case excelize.CellTypeDate:
+		// ISO 8601 text, as written by some tools
+		if t, ok := parseTime(raw); ok {
+			return t, nil
+		}
+		return raw, nil
+	case excelize.CellTypeNumber, excelize.CellTypeUnset:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return raw, nil
+		}
+		if c.isDate(cell) {
+			return excelize.ExcelDateToTime(n, c.date1904)
+		}
+		return json.Number(raw), nil
+	default:
+		return raw, nil
+	}
+}
+
+// isDate reports whether the number format of cell displays a date.
+func (c *xlsxCells) isDate(cell string) bool {
+	idx, err := c.f.GetCellStyle(c.sheet, cell)
+	if err != nil || idx == 0 {
+		return false
+	}
+	if isDate, ok := c.dateStyles[idx]; ok {
+		return isDate
+	}
+	isDate := false
+	if style, err := c.f.GetStyle(idx); err == nil {
+		if style.CustomNumFmt != nil {
+			isDate = isDateFormat(*style.CustomNumFmt)
+		} else {
+			isDate = isBuiltinDateFormat(style.NumFmt)
+		}
+	}
+	c.dateStyles[idx] = isDate
+	return isDate
+}
+
+// isBuiltinDateFormat reports whether a built-in number format id is one of
+// the date and time formats defined by the spreadsheet standard.
+func isBuiltinDateFormat(id int) bool {
+	return (id >= 14 && id <= 22) || (id >= 45 && id <= 47)
+}
+
+// isDateFormat reports whether a custom number format shows date or time
+// parts, ignoring quoted literals, escapes and [color] sections.
+func isDateFormat(format string) bool {
+	inQuote, inBracket := false, false
+	for i := 0; i < len(format); i++ {
+		ch := format[i]
+		switch {
+		case ch == '"':
+			inQuote = !inQuote
+		case inQuote:
+		case ch == '\\':
+			i++
+		case ch == '[':
+			inBracket = true
+		case ch == ']':
+			inBracket = false
+		case inBracket:
+		case strings.ContainsRune("dmyhsDMYHS", rune(ch)):
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package parse
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// buildXLSX returns a workbook with a typed "People" sheet and a second
+// "Notes" sheet.
+func buildXLSX(t *testing.T) []byte {
+	t.Helper()
+	f := excelize.NewFile()
+	defer func() { _ = f.Close() }()
+	if err := f.SetSheetName("Sheet1", "People"); err != nil {
+		t.Fatal(err)
+	}
+	rows := [][]any{
+		{"name", "age", "active", "joined", "score"},
+		{"Alice", 30, true, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), 9.5},
+		{},
+		{"Bob", 25, false, nil, nil},
+	}
+	for i, r := range rows {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := f.SetSheetRow("People", cell, &r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := f.NewSheet("Notes"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.SetSheetRow("Notes", "A1", &[]any{"note"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.SetSheetRow("Notes", "A2", &[]any{"hello"}); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := f.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestParseXLSX(t *testing.T) {
+	got, err := Parse(buildXLSX(t), XLSX, ParseOptions{})
+	if err != nil {
+		t.Fatalf("parse xlsx: %v", err)
+	}
+	want := []map[string]any{
+		{"name": "Alice", "age": json.Number("30"), "active": true, "joined": time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), "score": json.Number("9.5")},
+		{"name": "Bob", "age": json.Number("25"), "active": false},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestParseXLSX_Sheet(t *testing.T) {
+	data := buildXLSX(t)
+	for _, sheet := range []string{"Notes", "2"} {
+		got, err := Parse(data, XLSX, ParseOptions{Sheet: sheet})
+		if err != nil {
+			t.Fatalf("parse xlsx: %v", err)
+		}
+		if want := []map[string]any{{"note": "hello"}}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("sheet %s: got %v", sheet, got)
+		}
+	}
+
+	_, err := Parse(data, XLSX, ParseOptions{Sheet: "Missing"})
+	if err == nil || !strings.Contains(err.Error(), "available sheets: People, Notes") {
+		t.Fatalf("expected sheet not found error, got %v", err)
+	}
+}
+
+func TestParseXLSX_NoHeader(t *testing.T) {
+	got, err := Parse(buildXLSX(t), XLSX, ParseOptions{Sheet: "Notes", CSVNoHeader: true})
+	if err != nil {
+		t.Fatalf("parse xlsx: %v", err)
+	}
+	if want := []map[string]any{{"col0": "note"}, {"col0": "hello"}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestIsDateFormat(t *testing.T) {
+	tests := map[string]bool{
+		"yyyy-mm-dd":       true,
+		"[h]:mm:ss":        true,
+		"0.00":             false,
+		"General":          false,
+		`"day "0`:          false,
+		"[Red]#,##0.00":    false,
+		`#,##0\ "d"`:       false,
+		"dd/mm/yyyy hh:mm": true,
+	}
+	for format, want := range tests {
+		if got := isDateFormat(format); got != want {
+			t.Errorf("isDateFormat(%q) = %v, want %v", format, got, want)
+		}
+	}
+}
+
+func TestDetect_XLSX(t *testing.T) {
+	if got := (Detector{FilePath: "report.XLSX"}).Detect(nil); got != XLSX {
+		t.Fatalf("extension: got %v", got)
+	}
+	if got := (Detector{}).Detect(buildXLSX(t)); got != XLSX {
+		t.Fatalf("sniff: got %v", got)
+	}
+}
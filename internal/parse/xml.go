@@ -0,0 +1,144 @@
+package parse
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/text/encoding/htmlindex"
+)
+
+// parseXML converts an XML document to nested maps keyed by element name.
+// Attributes become "@name" keys, text content next to attributes or child
+// elements becomes "#text", and repeated sibling elements become arrays.
+// Elements holding only text map to that text, and empty ones to nil.
+//
+// With an XMLRowPath such as "catalog.book", the elements at that path are
+// returned as rows instead of the whole document.
+func parseXML(data []byte, opts ParseOptions) (any, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	dec.CharsetReader = xmlCharsetReader
+	if opts.Transcoded {
+		// the declaration names the charset the input was converted from
+		dec.CharsetReader = func(_ string, r io.Reader) (io.Reader, error) { return r, nil }
+	}
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("no root element")
+		}
+		if err != nil {
+			return nil, err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		root, err := decodeXMLElement(dec, start)
+		if err != nil {
+			return nil, err
+		}
+		doc := map[string]any{start.Name.Local: root}
+		if opts.XMLRowPath == "" {
+			return doc, nil
+		}
+		rows := xmlRows(doc, strings.Split(opts.XMLRowPath, "."))
+		if len(rows) == 0 {
+			return nil, fmt.Errorf("xml row path %q not found", opts.XMLRowPath)
+		}
+		return rows, nil
+	}
+}
+
+func decodeXMLElement(dec *xml.Decoder, start xml.StartElement) (any, error) {
+	m := make(map[string]any)
+	for _, a := range start.Attr {
+		if a.Name.Space == "xmlns" || a.Name.Local == "xmlns" {
+			continue
+		}
+		m["@"+a.Name.Local] = a.Value
+	}
+	var text strings.Builder
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			child, err := decodeXMLElement(dec, t)
+			if err != nil {
+				return nil, err
+			}
+			name := t.Name.Local
+			switch existing := m[name].(type) {
+			case nil:
+				if _, ok := m[name]; ok {
+					m[name] = []any{nil, child}
+				} else {
+					m[name] = child
+				}
+			case []any:
+				m[name] = append(existing, child)
+			default:
+				m[name] = []any{existing, child}
+			}
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			s := strings.TrimSpace(text.String())
+			if len(m) == 0 {
+				if s == "" {
+					return nil, nil
+				}
+				return s, nil
+			}
+			if s != "" {
+				m["#text"] = s
+			}
+			return m, nil
+		}
+	}
+}
+
+// xmlRows collects the values at path, descending into every element of
+// repeated elements along the way. Arrays found at the end of the path are
+// expanded so each element becomes a row.
+func xmlRows(v any, path []string) []any {
+	if arr, ok := v.([]any); ok {
+		var out []any
+		for _, item := range arr {
+			out = append(out, xmlRows(item, path)...)
+		}
+		return out
+	}
+	if len(path) == 0 {
+		return []any{v}
+	}
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil
+	}
+	child, ok := m[path[0]]
+	if !ok {
+		return nil
+	}
+	return xmlRows(child, path[1:])
+}
+
+// xmlCharsetReader honors the encoding named in the XML declaration. UTF-8
+// and UTF-16 input has already been transcoded by the input reader.
+func xmlCharsetReader(label string, r io.Reader) (io.Reader, error) {
+	switch strings.ToLower(label) {
+	case "utf-8", "utf8", "utf-16", "utf-16le", "utf-16be":
+		return r, nil
+	}
+	enc, err := htmlindex.Get(strings.TrimSpace(label))
+	if err != nil {
+		return nil, fmt.Errorf("unsupported encoding %q", label)
+	}
+	return enc.NewDecoder().Reader(r), nil
+}
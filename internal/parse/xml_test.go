@@ -0,0 +1,125 @@
+package parse
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const catalogXML = `<?xml version="1.0"?>
+<catalog xmlns="urn:books">
+  <book id="bk101" lang="en">
+    <author>Gambardella</author>
+    <title>XML Guide</title>
+    <price currency="USD">44.95</price>
+  </book>
+  <book id="bk102">
+    <author>Ralls</author>
+    <tags><tag>fantasy</tag><tag>novel</tag></tags>
+    <note/>
+  </book>
+</catalog>`
+
+func TestParseXML_Document(t *testing.T) {
+	v, err := Parse([]byte(catalogXML), XML, ParseOptions{})
+	if err != nil {
+		t.Fatalf("parse xml: %v", err)
+	}
+	books := v.(map[string]any)["catalog"].(map[string]any)["book"].([]any)
+	if len(books) != 2 {
+		t.Fatalf("want 2 books, got %d", len(books))
+	}
+	want := map[string]any{
+		"@id":    "bk101",
+		"@lang":  "en",
+		"author": "Gambardella",
+		"title":  "XML Guide",
+		"price":  map[string]any{"@currency": "USD", "#text": "44.95"},
+	}
+	if !reflect.DeepEqual(books[0], want) {
+		t.Fatalf("got %v, want %v", books[0], want)
+	}
+	second := books[1].(map[string]any)
+	if !reflect.DeepEqual(second["tags"], map[string]any{"tag": []any{"fantasy", "novel"}}) {
+		t.Fatalf("repeated elements should be an array, got %v", second["tags"])
+	}
+	if note, ok := second["note"]; !ok || note != nil {
+		t.Fatalf("empty element should be nil, got %v", note)
+	}
+}
+
+func TestParseXML_RowPath(t *testing.T) {
+	v, err := Parse([]byte(catalogXML), XML, ParseOptions{XMLRowPath: "catalog.book"})
+	if err != nil {
+		t.Fatalf("parse xml: %v", err)
+	}
+	rows, ok := v.([]any)
+	if !ok || len(rows) != 2 || !ArrayIsObjects(rows) {
+		t.Fatalf("want 2 object rows, got %v", v)
+	}
+
+	// Paths descend through every repeated element
+	v, err = Parse([]byte(catalogXML), XML, ParseOptions{XMLRowPath: "catalog.book.author"})
+	if err != nil {
+		t.Fatalf("parse xml: %v", err)
+	}
+	if !reflect.DeepEqual(v, []any{"Gambardella", "Ralls"}) {
+		t.Fatalf("got %v", v)
+	}
+
+	_, err = Parse([]byte(catalogXML), XML, ParseOptions{XMLRowPath: "catalog.magazine"})
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestParseXML_Charset(t *testing.T) {
+	data := []byte("<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><p>caf\xe9</p>")
+	v, err := Parse(data, XML, ParseOptions{})
+	if err != nil {
+		t.Fatalf("parse xml: %v", err)
+	}
+	if got := v.(map[string]any)["p"]; got != "café" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestParseXML_Transcoded(t *testing.T) {
+	// converted to UTF-8 already, despite what the declaration says
+	data := []byte("<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><p>café</p>")
+	v, err := Parse(data, XML, ParseOptions{Transcoded: true})
+	if err != nil {
+		t.Fatalf("parse xml: %v", err)
+	}
+	if got := v.(map[string]any)["p"]; got != "café" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestParseXML_Invalid(t *testing.T) {
+	for _, data := range []string{"<a><b></a>", "", "<!-- only a comment -->"} {
+		if _, err := Parse([]byte(data), XML, ParseOptions{}); err == nil {
+			t.Fatalf("expected error for %q", data)
+		}
+	}
+}
+
+func TestDetect_XML(t *testing.T) {
+	tests := []struct {
+		name string
+		d    Detector
+		data string
+	}{
+		{"extension", Detector{FilePath: "pom.xml"}, "a: 1"},
+		{"explicit", Detector{Explicit: "xml"}, "{}"},
+		{"content type", Detector{ContentType: "application/soap+xml; charset=utf-8"}, ""},
+		{"sniff", Detector{}, "\n  <?xml version=\"1.0\"?><a/>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.Detect([]byte(tt.data)); got != XML {
+				t.Fatalf("want xml got %v", got)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"html"
 	"strconv"
 	"strings"
+	"time"
 
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -13,6 +14,7 @@ import (
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/jedib0t/go-pretty/v6/text"
 	"github.com/sriharip316/tablo/internal/flatten"
+	"github.com/sriharip316/tablo/internal/timefmt"
 )
 
 type Mode int
@@ -265,23 +267,28 @@ func wrapEnforcer(o Options) table.WidthEnforcer {
 	case "off":
 		fallthrough
 	default:
-		// truncate with suffix
-		return func(s string, width int) string {
-			if width <= 0 {
-				return s
-			}
-			r := []rune(s)
-			if len(r) <= width {
-				return s
-			}
-			suf := o.TruncateSuffix
-			if len([]rune(suf)) > width {
-				suf = ""
-			}
-			keep := width - len([]rune(suf))
-			keep = max(keep, 0)
-			return string(r[:keep]) + suf
+		return truncateEnforcer(o)
+	}
+}
+
+// truncateEnforcer cuts cells to the column width, ending them with the
+// configured truncation suffix.
+func truncateEnforcer(o Options) table.WidthEnforcer {
+	return func(s string, width int) string {
+		if width <= 0 {
+			return s
+		}
+		r := []rune(s)
+		if len(r) <= width {
+			return s
+		}
+		suf := o.TruncateSuffix
+		if len([]rune(suf)) > width {
+			suf = ""
 		}
+		keep := width - len([]rune(suf))
+		keep = max(keep, 0)
+		return string(r[:keep]) + suf
 	}
 }
 
@@ -336,6 +343,8 @@ func formatCell(v any, o Options) any {
 		return escapeHTML(t.String(), o)
 	case string:
 		return escapeHTML(t, o)
+	case time.Time:
+		return escapeHTML(timefmt.Format(t), o)
 	case fmt.Stringer:
 		return escapeHTML(t.String(), o)
 	default:
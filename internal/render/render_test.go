@@ -4,6 +4,7 @@ import (
 	stdjson "encoding/json"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/jedib0t/go-pretty/v6/text"
@@ -311,6 +312,14 @@ func TestFormatCellBranches(t *testing.T) {
 	if v := formatCell("hello", Options{}); v != "hello" {
 		t.Fatalf("string passthrough failed: %v", v)
 	}
+
+	// timestamps render as RFC 3339, dates without a time of day
+	if v := formatCell(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Options{}); v != "2024-03-01" {
+		t.Fatalf("date formatting failed: %v", v)
+	}
+	if v := formatCell(time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC), Options{}); v != "2024-03-01T09:30:00Z" {
+		t.Fatalf("timestamp formatting failed: %v", v)
+	}
 }
 
 func TestRenderObjectKV_WithWrapping(t *testing.T) {
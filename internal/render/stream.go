@@ -0,0 +1,203 @@
+package render
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+// Stream renders rows one at a time with a fixed layout, for input that is
+// still being written. Column widths are computed once from the headers and
+// a sample of rows (capped by MaxColWidth); longer cells in later rows are
+// truncated to fit.
+type Stream struct {
+	w       io.Writer
+	o       Options
+	style   table.Style
+	headers []string
+	widths  []int
+	index   bool
+	rows    int
+	csv     *csv.Writer
+}
+
+// NewStream writes the table header to w and returns a Stream for its rows.
+// The sample rows only size the columns; write them with WriteRow as well.
+// HTML output cannot be streamed.
+func NewStream(w io.Writer, headers []string, sample [][]any, index bool, o Options) (*Stream, error) {
+	s := &Stream{w: w, o: o, style: resolveStyle(o), index: index}
+	switch strings.ToLower(o.Style) {
+	case "html":
+		return nil, fmt.Errorf("style %q cannot be streamed", o.Style)
+	case "csv":
+		s.csv = csv.NewWriter(w)
+	}
+
+	if index {
+		s.headers = append(s.headers, "")
+	}
+	for _, h := range headers {
+		s.headers = append(s.headers, headerCase(h, o.HeaderCase))
+	}
+
+	s.widths = make([]int, len(s.headers))
+	for i, h := range s.headers {
+		s.widths[i] = text.StringWidthWithoutEscSequences(h)
+	}
+	for n, r := range sample {
+		for i, c := range s.cells(r, n+1) {
+			s.widths[i] = max(s.widths[i], text.StringWidthWithoutEscSequences(c))
+		}
+	}
+	if o.MaxColWidth > 0 {
+		for i := range s.widths {
+			s.widths[i] = min(s.widths[i], o.MaxColWidth)
+		}
+	}
+
+	return s, s.writeHeader()
+}
+
+// WriteRow renders a single row.
+func (s *Stream) WriteRow(row []any) error {
+	s.rows++
+	return s.writeLine(s.cells(row, s.rows))
+}
+
+// Close finishes the table, drawing its bottom border if the style has one.
+func (s *Stream) Close() error {
+	if s.csv != nil || s.isMarkdown() || !s.style.Options.DrawBorder {
+		return nil
+	}
+	b := s.style.Box
+	return s.writeRaw(s.rule(b.BottomLeft, b.BottomSeparator, b.BottomRight))
+}
+
+func (s *Stream) writeHeader() error {
+	if s.csv != nil {
+		if s.o.NoHeader {
+			return nil
+		}
+		return s.writeLine(s.headers)
+	}
+	if s.isMarkdown() {
+		if err := s.writeLine(s.headers); err != nil {
+			return err
+		}
+		seps := make([]string, len(s.headers))
+		for i := range seps {
+			seps[i] = " --- "
+		}
+		if s.index {
+			seps[0] = " ---:"
+		}
+		return s.writeRaw("|" + strings.Join(seps, "|") + "|")
+	}
+
+	b := s.style.Box
+	if s.style.Options.DrawBorder {
+		if err := s.writeRaw(s.rule(b.TopLeft, b.TopSeparator, b.TopRight)); err != nil {
+			return err
+		}
+	}
+	if s.o.NoHeader {
+		return nil
+	}
+	if err := s.writeLine(s.headers); err != nil {
+		return err
+	}
+	if s.style.Options.SeparateHeader {
+		return s.writeRaw(s.rule(b.LeftSeparator, b.MiddleSeparator, b.RightSeparator))
+	}
+	return nil
+}
+
+// cells formats the values of a row, prefixed with its number when the
+// index column is enabled.
+func (s *Stream) cells(row []any, n int) []string {
+	out := make([]string, 0, len(row)+1)
+	if s.index {
+		out = append(out, strconv.Itoa(n))
+	}
+	for _, v := range row {
+		c := ""
+		if f := formatCell(v, s.o); f != nil {
+			c = fmt.Sprint(f)
+		}
+		out = append(out, strings.ReplaceAll(c, "\n", " "))
+	}
+	return out
+}
+
+func (s *Stream) writeLine(cells []string) error {
+	if s.csv != nil {
+		if err := s.csv.Write(cells); err != nil {
+			return err
+		}
+		s.csv.Flush()
+		return s.csv.Error()
+	}
+
+	truncate := truncateEnforcer(s.o)
+	if s.isMarkdown() {
+		parts := make([]string, len(cells))
+		for i, c := range cells {
+			if s.o.MaxColWidth > 0 {
+				c = truncate(c, s.o.MaxColWidth)
+			}
+			parts[i] = " "
+			if c != "" {
+				parts[i] = " " + strings.ReplaceAll(c, "|", `\|`) + " "
+			}
+		}
+		return s.writeRaw("|" + strings.Join(parts, "|") + "|")
+	}
+
+	b := s.style.Box
+	parts := make([]string, len(cells))
+	for i, c := range cells {
+		parts[i] = b.PaddingLeft + text.Pad(truncate(c, s.widths[i]), s.widths[i], ' ') + b.PaddingRight
+	}
+	sep := ""
+	if s.style.Options.SeparateColumns {
+		sep = b.MiddleVertical
+	}
+	line := strings.Join(parts, sep)
+	if s.style.Options.DrawBorder {
+		line = b.Left + line + b.Right
+	}
+	return s.writeRaw(line)
+}
+
+// rule draws a horizontal border line using the given corner and junction
+// characters.
+func (s *Stream) rule(left, junction, right string) string {
+	b := s.style.Box
+	pad := text.StringWidthWithoutEscSequences(b.PaddingLeft + b.PaddingRight)
+	parts := make([]string, len(s.widths))
+	for i, w := range s.widths {
+		parts[i] = strings.Repeat(b.MiddleHorizontal, w+pad)
+	}
+	if !s.style.Options.SeparateColumns {
+		junction = b.MiddleHorizontal
+	}
+	line := strings.Join(parts, junction)
+	if s.style.Options.DrawBorder {
+		line = left + line + right
+	}
+	return line
+}
+
+func (s *Stream) writeRaw(line string) error {
+	_, err := io.WriteString(s.w, line+"\n")
+	return err
+}
+
+func (s *Stream) isMarkdown() bool {
+	return strings.ToLower(s.o.Style) == "markdown"
+}
@@ -0,0 +1,71 @@
+package render
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func streamRows(t *testing.T, headers []string, rows [][]any, index bool, o Options) string {
+	t.Helper()
+	var buf bytes.Buffer
+	s, err := NewStream(&buf, headers, rows, index, o)
+	if err != nil {
+		t.Fatalf("new stream: %v", err)
+	}
+	for _, r := range rows {
+		if err := s.WriteRow(r); err != nil {
+			t.Fatalf("write row: %v", err)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	return buf.String()
+}
+
+func TestStream_MatchesRender(t *testing.T) {
+	headers := []string{"name", "age"}
+	rows := [][]any{{"Alice", 30}, {"Bob", nil}, {"Carol", true}}
+	for _, style := range []string{"heavy", "light", "double", "ascii", "compact", "borderless", "markdown", "csv"} {
+		for _, index := range []bool{false, true} {
+			o := Options{Style: style, NullStr: "null", BoolStr: "yes:no"}
+			want, err := Render(Model{Mode: ModeRows, Headers: headers, Rows: rows, IndexColumn: index}, o)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := streamRows(t, headers, rows, index, o)
+			if strings.TrimRight(got, "\n") != strings.TrimRight(want, "\n") {
+				t.Errorf("style=%s index=%v\ngot:\n%s\nwant:\n%s", style, index, got, want)
+			}
+		}
+	}
+}
+
+func TestStream_FixedWidthsTruncateLaterRows(t *testing.T) {
+	var buf bytes.Buffer
+	o := Options{Style: "ascii", TruncateSuffix: "~"}
+	s, err := NewStream(&buf, []string{"msg"}, [][]any{{"short"}}, false, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.WriteRow([]any{"a much longer message"}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "| a mu~ |") {
+		t.Fatalf("expected truncated cell, got:\n%s", buf.String())
+	}
+}
+
+func TestStream_MaxColWidth(t *testing.T) {
+	got := streamRows(t, []string{"msg"}, [][]any{{"abcdefghij"}}, false, Options{Style: "ascii", MaxColWidth: 4, TruncateSuffix: "…"})
+	if !strings.Contains(got, "| abc… |") {
+		t.Fatalf("expected cell capped at 4 columns, got:\n%s", got)
+	}
+}
+
+func TestStream_HTMLUnsupported(t *testing.T) {
+	if _, err := NewStream(&bytes.Buffer{}, []string{"a"}, nil, false, Options{Style: "html"}); err == nil {
+		t.Fatal("expected error for html style")
+	}
+}
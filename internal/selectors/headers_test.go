@@ -0,0 +1,33 @@
+package selectors_test
+
+import (
+	"testing"
+
+	"github.com/sriharip316/tablo/internal/flatten"
+	"github.com/sriharip316/tablo/internal/selectors"
+)
+
+func TestHeadersUnionOrder(t *testing.T) {
+	rows := []flatten.FlatKV{
+		{"a": 1, "b": 2},
+		{"b": 3, "c": 4},
+		{"a": 5, "d": 6, "c": 7},
+	}
+	got := selectors.HeadersUnion(rows)
+	// expected order: a (from row1), b (row1), c (row2), d (row3)
+	exp := []string{"a", "b", "c", "d"}
+	if len(got) != len(exp) {
+		t.Fatalf("len mismatch got=%v exp=%v", got, exp)
+	}
+	for i, k := range exp {
+		if got[i] != k {
+			t.Fatalf("order mismatch got=%v exp=%v", got, exp)
+		}
+	}
+}
+
+func TestHeadersUnionEmpty(t *testing.T) {
+	if got := selectors.HeadersUnion([]flatten.FlatKV{}); len(got) != 0 {
+		t.Fatalf("expected empty got=%v", got)
+	}
+}
@@ -1,16 +1,20 @@
 package selectors
 
 import (
+	"fmt"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 
-	"github.com/sriharip316/tablo/internal/flatten"
+	"github.com/sriharip316/tablo/internal/keypath"
 )
 
 type Expr struct {
 	Raw   string
 	parts []segment
+	sep   string
 }
 
 var (
@@ -24,13 +28,20 @@ type segment struct {
 }
 
 func CompileMany(exprs []string) ([]Expr, error) {
+	return CompileManySep(exprs, keypath.DefaultSeparator)
+}
+
+// CompileManySep compiles path expressions whose segments are separated by
+// sep. Segments in quotes or brackets, as in labels."app.kubernetes.io/name",
+// are matched literally.
+func CompileManySep(exprs []string, sep string) ([]Expr, error) {
 	out := make([]Expr, 0, len(exprs))
 	for _, e := range exprs {
 		e = strings.TrimSpace(e)
 		if e == "" {
 			continue
 		}
-		ex, err := compileOne(e)
+		ex, err := compileOne(e, sep)
 		if err != nil {
 			return nil, err
 		}
@@ -39,12 +50,16 @@ func CompileMany(exprs []string) ([]Expr, error) {
 	return out, nil
 }
 
-func compileOne(e string) (Expr, error) {
-	segs := strings.Split(e, ".")
+func compileOne(e, sep string) (Expr, error) {
+	segs, err := keypath.Parse(e, sep)
+	if err != nil {
+		return Expr{}, err
+	}
 	parts := make([]segment, len(segs))
-	for i, s := range segs {
+	for i, seg := range segs {
+		s := seg.Name
 		// translate globs to regex
-		if strings.ContainsAny(s, "*?") {
+		if !seg.Quoted && strings.ContainsAny(s, "*?") {
 			if val, ok := regexCache.Load(s); ok {
 				parts[i] = segment{pattern: val.(*regexp.Regexp)}
 				continue
@@ -61,7 +76,7 @@ func compileOne(e string) (Expr, error) {
 			parts[i] = segment{literal: s}
 		}
 	}
-	return Expr{Raw: e, parts: parts}, nil
+	return Expr{Raw: e, parts: parts, sep: sep}, nil
 }
 
 func globToRegex(s string) string {
@@ -105,35 +120,38 @@ func ApplyToKeys(keys []string, include []Expr, exclude []Expr) []string {
 }
 
 func matchesAny(key string, exprs []Expr) bool {
-	segs := strings.Split(key, ".")
-	for _, ex := range exprs {
-		if len(ex.parts) != len(segs) {
-			continue
+	var segs []string
+	for i, ex := range exprs {
+		if i == 0 || ex.sep != exprs[i-1].sep {
+			segs = splitKey(key, ex.sep)
 		}
-		ok := true
-		for i := range segs {
-			p := ex.parts[i]
-			if p.literal != "" {
-				if p.literal != segs[i] {
-					ok = false
-					break
-				}
-			} else if p.pattern != nil {
-				if !p.pattern.MatchString(segs[i]) {
-					ok = false
-					break
-				}
-			}
-		}
-		if ok {
+		if len(ex.parts) == len(segs) && segmentsMatch(ex.parts, segs) {
 			return true
 		}
 	}
 	return false
 }
 
+// splitKey splits a flattened key into the names of its segments.
+func splitKey(key, sep string) []string {
+	if segs, err := keypath.Split(key, sep); err == nil {
+		return segs
+	}
+	return strings.Split(key, sep)
+}
+
+// segmentsMatch reports whether each name matches the segment at its index.
+func segmentsMatch(parts []segment, names []string) bool {
+	for i, name := range names {
+		if !parts[i].matches(name) {
+			return false
+		}
+	}
+	return true
+}
+
 // HeadersUnion returns the union of keys across rows in natural order of first occurrence.
-func HeadersUnion(rows []flatten.FlatKV) []string {
+func HeadersUnion[Row interface{ Keys() []string }](rows []Row) []string {
 	order := []string{}
 	seen := map[string]struct{}{}
 	for _, r := range rows {
@@ -166,3 +184,94 @@ func MissingExpressions(keys []string, include []Expr) []string {
 	}
 	return missing
 }
+
+// Root returns the first segment of the expression when it is a literal
+// name rather than a glob pattern.
+func (e Expr) Root() (string, bool) {
+	if len(e.parts) == 0 || e.parts[0].pattern != nil {
+		return "", false
+	}
+	return e.parts[0].literal, true
+}
+
+// HasGlob reports whether any segment of the expression is a glob pattern.
+func (e Expr) HasGlob() bool {
+	for _, p := range e.parts {
+		if p.pattern != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Covers reports whether path, given as segments, is the expression's path
+// or lies below it.
+func (e Expr) Covers(path []string) bool {
+	return len(path) >= len(e.parts) && segmentsMatch(e.parts, path[:len(e.parts)])
+}
+
+// Leads reports whether path, given as segments, lies above the
+// expression's path, on the way to it.
+func (e Expr) Leads(path []string) bool {
+	return len(path) < len(e.parts) && segmentsMatch(e.parts, path)
+}
+
+// Find returns the values found at the expression's path within a parsed
+// document. Segments name object keys or array indices; glob segments match
+// every key or index they fit, visiting object keys in sorted order.
+func (e Expr) Find(v any) []any {
+	return find(v, e.parts)
+}
+
+func find(v any, parts []segment) []any {
+	if len(parts) == 0 {
+		return []any{v}
+	}
+	p, rest := parts[0], parts[1:]
+	var out []any
+	switch t := v.(type) {
+	case map[string]any:
+		if p.pattern == nil {
+			if child, ok := t[p.literal]; ok {
+				return find(child, rest)
+			}
+			return nil
+		}
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if p.pattern.MatchString(k) {
+				out = append(out, find(t[k], rest)...)
+			}
+		}
+	case map[any]any:
+		m := make(map[string]any, len(t))
+		for k, vv := range t {
+			m[fmt.Sprint(k)] = vv
+		}
+		return find(m, parts)
+	case []any:
+		for i, item := range t {
+			if p.matches(strconv.Itoa(i)) {
+				out = append(out, find(item, rest)...)
+			}
+		}
+	case []map[string]any:
+		for i, item := range t {
+			if p.matches(strconv.Itoa(i)) {
+				out = append(out, find(item, rest)...)
+			}
+		}
+	}
+	return out
+}
+
+func (s segment) matches(name string) bool {
+	if s.pattern != nil {
+		return s.pattern.MatchString(name)
+	}
+	return s.literal == name
+}
@@ -1,9 +1,8 @@
 package selectors
 
 import (
+	"reflect"
 	"testing"
-
-	"github.com/sriharip316/tablo/internal/flatten"
 )
 
 func TestCompileAndMatch(t *testing.T) {
@@ -53,31 +52,6 @@ func TestCompileMany_GlobWeirdButValid(t *testing.T) {
 	}
 }
 
-func TestHeadersUnionOrder(t *testing.T) {
-	rows := []flatten.FlatKV{
-		{"a": 1, "b": 2},
-		{"b": 3, "c": 4},
-		{"a": 5, "d": 6, "c": 7},
-	}
-	got := HeadersUnion(rows)
-	// expected order: a (from row1), b (row1), c (row2), d (row3)
-	exp := []string{"a", "b", "c", "d"}
-	if len(got) != len(exp) {
-		t.Fatalf("len mismatch got=%v exp=%v", got, exp)
-	}
-	for i, k := range exp {
-		if got[i] != k {
-			t.Fatalf("order mismatch got=%v exp=%v", got, exp)
-		}
-	}
-}
-
-func TestHeadersUnionEmpty(t *testing.T) {
-	if got := HeadersUnion([]flatten.FlatKV{}); len(got) != 0 {
-		t.Fatalf("expected empty got=%v", got)
-	}
-}
-
 func TestApplyToKeys_ExcludeOnly(t *testing.T) {
 	keys := []string{"a", "b.c", "b.d", "c.e"}
 	exc, err := CompileMany([]string{"b.*"})
@@ -98,3 +72,55 @@ func TestApplyToKeys_NoFilters(t *testing.T) {
 		t.Fatalf("unexpected passthrough: %v", out)
 	}
 }
+
+func TestExprRoot(t *testing.T) {
+	exprs, err := CompileMany([]string{"user.name", "tags", "*.id", "a?.b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		root string
+		ok   bool
+	}{{"user", true}, {"tags", true}, {"", false}, {"", false}}
+	for i, ex := range exprs {
+		root, ok := ex.Root()
+		if root != want[i].root || ok != want[i].ok {
+			t.Fatalf("%s: Root() = %q, %v; want %q, %v", ex.Raw, root, ok, want[i].root, want[i].ok)
+		}
+	}
+}
+
+func TestExprFind(t *testing.T) {
+	doc := map[string]any{
+		"data": map[string]any{"items": []any{map[string]any{"id": 1}, map[string]any{"id": 2}}},
+		"results": []any{
+			map[string]any{"hits": []any{"a", "b"}},
+			map[string]any{"hits": []any{"c"}},
+		},
+		"byName": map[any]any{"x": 1, "y": 2},
+	}
+	tests := []struct {
+		path string
+		want []any
+	}{
+		{"data.items.1.id", []any{2}},
+		{"results.*.hits", []any{[]any{"a", "b"}, []any{"c"}}},
+		{"results.1.hits.0", []any{"c"}},
+		{"byName.*", []any{1, 2}},
+		{"data.missing", nil},
+		{"data.items.5", nil},
+	}
+	for _, tt := range tests {
+		exprs, err := CompileMany([]string{tt.path})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := exprs[0].Find(doc); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Find(%q) = %#v, want %#v", tt.path, got, tt.want)
+		}
+	}
+	plain, _ := CompileMany([]string{"data.items", "results.*.hits"})
+	if plain[0].HasGlob() || !plain[1].HasGlob() {
+		t.Error("HasGlob mismatch")
+	}
+}
@@ -1,12 +1,15 @@
 package sort
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/sriharip316/tablo/internal/flatten"
+	"github.com/sriharip316/tablo/internal/timefmt"
 )
 
 // Options contains configuration for sorting rows
@@ -135,6 +138,13 @@ func compareValues(a, b any) int {
 		}
 	}
 
+	// Timestamps
+	if timeA, okA := a.(time.Time); okA {
+		if timeB, okB := b.(time.Time); okB {
+			return timeA.Compare(timeB)
+		}
+	}
+
 	// Booleans
 	if boolA, okA := toBool(a); okA {
 		if boolB, okB := toBool(b); okB {
@@ -186,6 +196,10 @@ func toNumber(v any) (float64, bool) {
 		return float64(val), true
 	case uint64:
 		return float64(val), true
+	case json.Number:
+		if f, err := val.Float64(); err == nil {
+			return f, true
+		}
 	case string:
 		if f, err := strconv.ParseFloat(val, 64); err == nil {
 			return f, true
@@ -223,6 +237,9 @@ func toString(v any) string {
 			return "true"
 		}
 		return "false"
+	case time.Time:
+		// as rendered, so dates compare with date strings
+		return timefmt.Format(val)
 	default:
 		return strings.ToLower(fmt.Sprintf("%v", val))
 	}
@@ -1,8 +1,10 @@
 package sort
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/sriharip316/tablo/internal/flatten"
 )
@@ -414,6 +416,10 @@ func TestCompareValues(t *testing.T) {
 		{"number vs string", 42, "hello", -1},
 		{"bool vs string", true, "false", 1},
 		{"string number vs number", "10", 5, 1}, // "10" as string vs 5 as number -> 10.0 > 5.0
+
+		// Timestamps
+		{"time less", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), -1},
+		{"time vs date string", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), "2024-03-02", -1},
 	}
 
 	for _, tt := range tests {
@@ -446,6 +452,7 @@ func TestToNumber(t *testing.T) {
 		{"float64", 3.14, 3.14, true},
 		{"float32", float32(2.5), 2.5, true},
 		{"string number", "123.45", 123.45, true},
+		{"json.Number", json.Number("100"), 100, true},
 		{"string invalid", "hello", 0, false},
 		{"bool", true, 0, false},
 		{"nil", nil, 0, false},
@@ -513,6 +520,8 @@ func TestToString(t *testing.T) {
 		{"bool false", false, "false"},
 		{"int", 42, "42"},
 		{"float", 3.14, "3.14"},
+		{"date", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), "2024-03-01"},
+		{"timestamp", time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC), "2024-03-01T09:30:00Z"},
 	}
 
 	for _, tt := range tests {
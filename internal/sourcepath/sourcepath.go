@@ -0,0 +1,44 @@
+// Package sourcepath inspects the names given to --file, which may be local
+// paths or http(s) URLs and may carry a compression extension, so that both
+// the reader and format detection agree on what they refer to.
+package sourcepath
+
+import (
+	"net/url"
+	"strings"
+)
+
+// compressionExts lists file extensions of compressed inputs.
+var compressionExts = []string{".gz", ".gzip", ".zst", ".zstd", ".bz2", ".xz"}
+
+// IsURL reports whether s is an http:// or https:// URL.
+func IsURL(s string) bool {
+	low := strings.ToLower(s)
+	return strings.HasPrefix(low, "http://") || strings.HasPrefix(low, "https://")
+}
+
+// URLPath returns the path component of an http(s) URL, dropping the query
+// and fragment, so format detection can look at its extension. Other
+// strings are returned unchanged.
+func URLPath(s string) string {
+	if !IsURL(s) {
+		return s
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return s
+	}
+	return u.Path
+}
+
+// TrimCompressionExt strips a compression extension from path, so that
+// "users.csv.gz" becomes "users.csv".
+func TrimCompressionExt(path string) string {
+	low := strings.ToLower(path)
+	for _, ext := range compressionExts {
+		if strings.HasSuffix(low, ext) {
+			return path[:len(path)-len(ext)]
+		}
+	}
+	return path
+}
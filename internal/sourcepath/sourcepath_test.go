@@ -0,0 +1,33 @@
+package sourcepath
+
+import "testing"
+
+func TestURLPath(t *testing.T) {
+	tests := map[string]string{
+		"https://example.com/data/users.csv?token=x#top": "/data/users.csv",
+		"HTTP://example.com/a.json":                      "/a.json",
+		"local/file.json":                                "local/file.json",
+	}
+	for in, want := range tests {
+		if got := URLPath(in); got != want {
+			t.Errorf("URLPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestTrimCompressionExt(t *testing.T) {
+	tests := map[string]string{
+		"users.csv.gz":     "users.csv",
+		"logs.JSONL.ZST":   "logs.JSONL",
+		"data.csv.bz2":     "data.csv",
+		"plain.json":       "plain.json",
+		"archive.yaml.xz":  "archive.yaml",
+		"dump.jsonl.zstd":  "dump.jsonl",
+		"export.json.gzip": "export.json",
+	}
+	for in, want := range tests {
+		if got := TrimCompressionExt(in); got != want {
+			t.Errorf("TrimCompressionExt(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+// Package timefmt writes timestamps the way tables show them. Filters and
+// sorting compare timestamps in the same form, so that a column shown as
+// 2024-03-01 matches the condition joined=2024-03-01.
+package timefmt
+
+import "time"
+
+// Format writes t as RFC 3339, or as a bare date when it is midnight UTC,
+// as for a value parsed from a date with no time of day.
+func Format(t time.Time) string {
+	if t.Location() == time.UTC && t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+		return t.Format(time.DateOnly)
+	}
+	return t.Format(time.RFC3339Nano)
+}
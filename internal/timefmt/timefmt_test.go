@@ -0,0 +1,23 @@
+package timefmt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		in   time.Time
+		want string
+	}{
+		{time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), "2024-03-01"},
+		{time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC), "2024-03-01T09:30:00Z"},
+		{time.Date(2024, 3, 1, 0, 0, 0, 500, time.UTC), "2024-03-01T00:00:00.0000005Z"},
+		{time.Date(2024, 3, 1, 0, 0, 0, 0, time.FixedZone("CET", 3600)), "2024-03-01T00:00:00+01:00"},
+	}
+	for _, tt := range tests {
+		if got := Format(tt.in); got != tt.want {
+			t.Errorf("Format(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}